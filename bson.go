@@ -0,0 +1,74 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package systems
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/systems/channel"
+)
+
+var (
+	_ bson.Getter = Base{}
+	_ bson.Setter = (*Base)(nil)
+)
+
+// bsonBase is the BSON document of a base. Series is only found in legacy
+// documents.
+type bsonBase struct {
+	Name    string          `bson:"name,omitempty"`
+	Channel channel.Channel `bson:"channel,omitempty"`
+	Series  string          `bson:"series,omitempty"`
+}
+
+// GetBSON implements bson.Getter. The base is stored as a compact canonical
+// document, e.g. {name: "ubuntu", channel: {track: "20.04", risk: "stable"}}.
+// The empty base is stored as null.
+func (s Base) GetBSON() (interface{}, error) {
+	if s == (Base{}) {
+		return nil, nil
+	}
+	if err := s.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	s = s.Canonical()
+	return bsonBase{Name: s.Name, Channel: s.Channel}, nil
+}
+
+// SetBSON implements bson.Setter. Besides the documents written by GetBSON,
+// it accepts legacy documents that only hold a series, e.g.
+// {series: "focal"}, and base strings. The decoded base is canonical.
+func (s *Base) SetBSON(raw bson.Raw) error {
+	var (
+		base Base
+		err  error
+	)
+	switch raw.Kind {
+	case channel.BSONKindNull:
+		return bson.SetZero
+	case channel.BSONKindString:
+		var str string
+		if err := raw.Unmarshal(&str); err != nil {
+			return errors.Trace(err)
+		}
+		base, err = ParseBaseFromSeries(str)
+	default:
+		var doc bsonBase
+		if err := raw.Unmarshal(&doc); err != nil {
+			return errors.Annotate(err, "invalid base document")
+		}
+		if doc.Name == "" && doc.Series != "" {
+			base, err = ParseBaseFromSeries(doc.Series)
+			break
+		}
+		base = Base{Name: doc.Name, Channel: doc.Channel}
+		err = base.Validate()
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	*s = base.Canonical()
+	return nil
+}
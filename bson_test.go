@@ -0,0 +1,107 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package systems_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/systems"
+)
+
+type bsonSuite struct{}
+
+var _ = gc.Suite(&bsonSuite{})
+
+type baseDoc struct {
+	Base systems.Base `bson:"base"`
+}
+
+func (s *bsonSuite) TestMarshal(c *gc.C) {
+	data, err := bson.Marshal(baseDoc{Base: mustBase("ubuntu", "20.04")})
+	c.Assert(err, jc.ErrorIsNil)
+	var doc bson.M
+	c.Assert(bson.Unmarshal(data, &doc), jc.ErrorIsNil)
+	c.Check(doc, jc.DeepEquals, bson.M{
+		"base": bson.M{
+			"name":    "ubuntu",
+			"channel": bson.M{"track": "20.04", "risk": "stable"},
+		},
+	})
+
+	// The latest track is omitted, as in the string form of the base.
+	data, err = bson.Marshal(baseDoc{Base: mustBase("genericlinux", "latest/stable")})
+	c.Assert(err, jc.ErrorIsNil)
+	doc = nil
+	c.Assert(bson.Unmarshal(data, &doc), jc.ErrorIsNil)
+	c.Check(doc, jc.DeepEquals, bson.M{
+		"base": bson.M{
+			"name":    "genericlinux",
+			"channel": bson.M{"risk": "stable"},
+		},
+	})
+}
+
+func (s *bsonSuite) TestMarshalInvalid(c *gc.C) {
+	_, err := bson.Marshal(baseDoc{Base: systems.Base{Name: "foo"}})
+	c.Check(err, gc.ErrorMatches, `os "foo" not valid`)
+}
+
+func (s *bsonSuite) TestRoundTrip(c *gc.C) {
+	for _, str := range []string{"focal", "centos/8/candidate", "ubuntu/22.04/edge/fix", "win10", "genericlinux"} {
+		base, err := systems.ParseBaseFromSeries(str)
+		c.Assert(err, jc.ErrorIsNil)
+		data, err := bson.Marshal(baseDoc{Base: base})
+		c.Assert(err, jc.ErrorIsNil)
+		var doc baseDoc
+		c.Assert(bson.Unmarshal(data, &doc), jc.ErrorIsNil)
+		c.Check(doc.Base, jc.DeepEquals, base)
+	}
+}
+
+func (s *bsonSuite) TestUnmarshal(c *gc.C) {
+	tests := []struct {
+		doc  bson.M
+		base systems.Base
+		err  string
+	}{{
+		doc:  bson.M{"base": bson.M{"series": "bionic"}},
+		base: mustBase("ubuntu", "18.04/stable"),
+	}, {
+		doc:  bson.M{"base": "focal"},
+		base: mustBase("ubuntu", "20.04/stable"),
+	}, {
+		doc:  bson.M{"base": bson.M{"name": "ubuntu", "channel": bson.M{"name": "20.04/stable", "track": "20.04", "risk": "stable"}}},
+		base: mustBase("ubuntu", "20.04/stable"),
+	}, {
+		doc:  bson.M{"base": bson.M{"name": "centos", "channel": "7"}},
+		base: mustBase("centos", "7/stable"),
+	}, {
+		doc:  bson.M{"base": nil},
+		base: systems.Base{},
+	}, {
+		doc: bson.M{"base": bson.M{"series": "foo"}},
+		err: `series "foo" not valid`,
+	}, {
+		doc: bson.M{"base": bson.M{"name": "ubuntu", "channel": bson.M{"risk": "foo"}}},
+		err: `invalid base document: invalid risk in channel: "foo"`,
+	}, {
+		doc: bson.M{"base": bson.M{"name": "ubuntu"}},
+		err: `channel not valid`,
+	}}
+	for i, test := range tests {
+		c.Logf("test %d: %v", i, test.doc)
+		data, err := bson.Marshal(test.doc)
+		c.Assert(err, jc.ErrorIsNil)
+		var doc baseDoc
+		err = bson.Unmarshal(data, &doc)
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			continue
+		}
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(doc.Base, jc.DeepEquals, test.base)
+	}
+}
@@ -5,6 +5,8 @@ package systems
 
 import (
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/juju/collections/set"
 
@@ -108,10 +110,12 @@ var seriesToBases = map[string]Base{
 		Name:    Windows,
 		Channel: channel.MustParse("win2008r2/stable"),
 	},
+	// Hyper-V Server 2012 R2.
 	"win2012hvr2": {
 		Name:    Windows,
 		Channel: channel.MustParse("win2012hvr2/stable"),
 	},
+	// Hyper-V Server 2012.
 	"win2012hv": {
 		Name:    Windows,
 		Channel: channel.MustParse("win2012hv/stable"),
@@ -164,16 +168,65 @@ var seriesToBases = map[string]Base{
 		Name:    CentOS,
 		Channel: channel.MustParse("centos8/stable"),
 	},
-	"opensuseleap": {
+	"opensuse42": {
 		Name:    OpenSUSE,
 		Channel: channel.MustParse("opensuse42/stable"),
 	},
+	// genericlinux has no versions, it is always the latest/stable channel.
 	"genericlinux": {
 		Name:    GenericLinux,
 		Channel: channel.MustParse("latest/stable"),
 	},
 }
 
+// SeriesAlias describes an alternative series name that resolves to the
+// same base as its preferred series name.
+type SeriesAlias struct {
+	// Alias is the alternative series name.
+	Alias string
+	// Preferred is the series name emitted by Base.String for the base.
+	Preferred string
+	// Deprecated is true when the alias should no longer be used.
+	Deprecated bool
+}
+
+// String returns a description of the alias, suitable for user warnings.
+func (a SeriesAlias) String() string {
+	if a.Deprecated {
+		return fmt.Sprintf("series %q is deprecated, use %q instead", a.Alias, a.Preferred)
+	}
+	return fmt.Sprintf("series %q is an alias of %q", a.Alias, a.Preferred)
+}
+
+// seriesAliases maps alternative series names to the preferred series found
+// in seriesToBases.
+var seriesAliases = map[string]SeriesAlias{
+	// opensuseleap always referred to the fixed opensuse42 channel.
+	"opensuseleap": {
+		Alias:      "opensuseleap",
+		Preferred:  "opensuse42",
+		Deprecated: true,
+	},
+}
+
+// LookupSeriesAlias returns the alias information for the series, or false
+// if the series is not an alias.
+func LookupSeriesAlias(series string) (SeriesAlias, bool) {
+	alias, ok := seriesAliases[series]
+	return alias, ok
+}
+
+// KnownSeries returns the sorted names of the series of the builtin
+// registry. Series aliases are not included.
+func KnownSeries() []string {
+	series := make([]string, 0, len(seriesToBases))
+	for s := range seriesToBases {
+		series = append(series, s)
+	}
+	sort.Strings(series)
+	return series
+}
+
 // baseToSeries is a reverse of seriesToBase
 var baseToSeries = reverseSeriesMap()
 
@@ -187,3 +240,72 @@ func reverseSeriesMap() map[Base]string {
 	}
 	return r
 }
+
+// seriesEOL holds the end of life date of known series. Series without an
+// entry have no announced end of life.
+var seriesEOL = map[string]time.Time{
+	"precise":     date(2017, time.April, 28),
+	"quantal":     date(2014, time.May, 16),
+	"raring":      date(2014, time.January, 27),
+	"saucy":       date(2014, time.July, 17),
+	"trusty":      date(2019, time.April, 25),
+	"utopic":      date(2015, time.July, 23),
+	"vivid":       date(2016, time.February, 4),
+	"wily":        date(2016, time.July, 28),
+	"xenial":      date(2021, time.April, 30),
+	"yakkety":     date(2017, time.July, 20),
+	"zesty":       date(2018, time.January, 13),
+	"artful":      date(2018, time.July, 19),
+	"bionic":      date(2023, time.May, 31),
+	"cosmic":      date(2019, time.July, 18),
+	"disco":       date(2020, time.January, 23),
+	"eoan":        date(2020, time.July, 17),
+	"focal":       date(2025, time.May, 29),
+	"groovy":      date(2021, time.July, 22),
+	"hirsute":     date(2022, time.January, 20),
+	"win2008r2":   date(2020, time.January, 14),
+	"win2012hvr2": date(2023, time.October, 10),
+	"win2012hv":   date(2023, time.October, 10),
+	"win2012r2":   date(2023, time.October, 10),
+	"win2012":     date(2023, time.October, 10),
+	"win2016":     date(2027, time.January, 12),
+	"win2016hv":   date(2027, time.January, 12),
+	"win2016nano": date(2027, time.January, 12),
+	"win2019":     date(2029, time.January, 9),
+	"win7":        date(2020, time.January, 14),
+	"win8":        date(2016, time.January, 12),
+	"win81":       date(2023, time.January, 10),
+	"win10":       date(2025, time.October, 14),
+	"centos7":     date(2024, time.June, 30),
+	"centos8":     date(2021, time.December, 31),
+	"opensuse42":  date(2019, time.July, 1),
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// releaseSeries returns the series of the OS release the base runs on,
+// ignoring its risk and branch. E.g. "ubuntu/20.04/edge" returns "focal".
+func releaseSeries(b Base) (string, bool) {
+	if b.Channel == channel.Empty {
+		return "", false
+	}
+	release := Base{
+		Name:    b.Name,
+		Channel: channel.Channel{Track: b.Channel.Track, Risk: channel.Stable}.Clean(),
+	}
+	series, ok := baseToSeries[release]
+	return series, ok
+}
+
+// EndOfLife returns the end of life date of the OS release the base runs on.
+// False is returned if the release is unknown or has no announced end of life.
+func (s Base) EndOfLife() (time.Time, bool) {
+	series, ok := releaseSeries(s)
+	if !ok {
+		return time.Time{}, false
+	}
+	eol, ok := seriesEOL[series]
+	return eol, ok
+}
@@ -0,0 +1,50 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package channel
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+)
+
+// DefaultBranchLifetime is how long a store branch lives when no explicit
+// expiry is set.
+const DefaultBranchLifetime = 30 * 24 * time.Hour
+
+// BranchExpiresAt returns when the branch the release is published to
+// expires. False is returned if the release is not on a branch or no
+// timestamps are known.
+func (r Release) BranchExpiresAt() (time.Time, bool) {
+	if r.Channel.Branch == "" {
+		return time.Time{}, false
+	}
+	if !r.ExpiresAt.IsZero() {
+		return r.ExpiresAt, true
+	}
+	if !r.CreatedAt.IsZero() {
+		return r.CreatedAt.Add(DefaultBranchLifetime), true
+	}
+	return time.Time{}, false
+}
+
+// Expired returns true if the release is on a branch that has expired at
+// the given time.
+func (r Release) Expired(now time.Time) bool {
+	expiresAt, ok := r.BranchExpiresAt()
+	return ok && !now.Before(expiresAt)
+}
+
+// ExpiredBranches returns the releases of the channel map published to
+// branches that have expired according to the clock.
+func (m ChannelMap) ExpiredBranches(clock clock.Clock) ChannelMap {
+	now := clock.Now()
+	var expired ChannelMap
+	for _, r := range m {
+		if r.Expired(now) {
+			expired = append(expired, r)
+		}
+	}
+	return expired
+}
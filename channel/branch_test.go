@@ -0,0 +1,92 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package channel_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/systems/channel"
+)
+
+type branchSuite struct{}
+
+var _ = gc.Suite(&branchSuite{})
+
+var branchEpoch = time.Date(2021, time.May, 1, 0, 0, 0, 0, time.UTC)
+
+func branchRelease(ch string, revision int, createdAt, expiresAt time.Time) channel.Release {
+	r := release(ch, revision)
+	r.CreatedAt = createdAt
+	r.ExpiresAt = expiresAt
+	return r
+}
+
+var branchChannelMap = channel.ChannelMap{
+	release("2.0/stable", 10),
+	release("2.0/edge", 12),
+	branchRelease("2.0/edge/old", 13, branchEpoch, time.Time{}),
+	branchRelease("2.0/edge/short", 14, branchEpoch, branchEpoch.Add(24*time.Hour)),
+	branchRelease("2.0/stable/hotfix", 15, branchEpoch.Add(20*24*time.Hour), time.Time{}),
+	release("2.0/beta/untracked", 16),
+}
+
+func (s *branchSuite) TestBranchExpiresAt(c *gc.C) {
+	expiresAt, ok := branchChannelMap[2].BranchExpiresAt()
+	c.Assert(ok, jc.IsTrue)
+	c.Check(expiresAt, gc.Equals, branchEpoch.Add(channel.DefaultBranchLifetime))
+
+	expiresAt, ok = branchChannelMap[3].BranchExpiresAt()
+	c.Assert(ok, jc.IsTrue)
+	c.Check(expiresAt, gc.Equals, branchEpoch.Add(24*time.Hour))
+
+	_, ok = branchChannelMap[0].BranchExpiresAt()
+	c.Check(ok, jc.IsFalse)
+	_, ok = branchChannelMap[5].BranchExpiresAt()
+	c.Check(ok, jc.IsFalse)
+}
+
+func (s *branchSuite) TestExpiredBranches(c *gc.C) {
+	clock := testclock.NewClock(branchEpoch.Add(12 * time.Hour))
+	c.Check(branchChannelMap.ExpiredBranches(clock), gc.HasLen, 0)
+
+	clock.Advance(24 * time.Hour)
+	c.Check(revisions(branchChannelMap.ExpiredBranches(clock)), jc.DeepEquals, []int{14})
+
+	clock.Advance(30 * 24 * time.Hour)
+	c.Check(revisions(branchChannelMap.ExpiredBranches(clock)), jc.DeepEquals, []int{13, 14})
+
+	clock.Advance(30 * 24 * time.Hour)
+	c.Check(revisions(branchChannelMap.ExpiredBranches(clock)), jc.DeepEquals, []int{13, 14, 15})
+}
+
+func revisions(m channel.ChannelMap) []int {
+	revs := []int{}
+	for _, r := range m {
+		revs = append(revs, r.Revision)
+	}
+	return revs
+}
+
+func (s *branchSuite) TestResolveExpiredBranch(c *gc.C) {
+	clock := testclock.NewClock(branchEpoch.Add(48 * time.Hour))
+	opts := channel.ResolveOptions{Clock: clock}
+
+	res, err := branchChannelMap.Resolve(mustParse(c, "2.0/edge/old"), opts)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res.Release.Revision, gc.Equals, 13)
+
+	res, err = branchChannelMap.Resolve(mustParse(c, "2.0/edge/short"), opts)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res.Release.Revision, gc.Equals, 12)
+	c.Check(res.String(), gc.Equals, "2.0/edge/short expired at 2021-05-02T00:00:00Z, falling back to edge, 2.0/edge is open at revision 12")
+
+	// Without a clock, expiry is not considered.
+	res, err = branchChannelMap.Resolve(mustParse(c, "2.0/edge/short"), channel.ResolveOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res.Release.Revision, gc.Equals, 14)
+}
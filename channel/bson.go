@@ -0,0 +1,92 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package channel
+
+import (
+	"fmt"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+var (
+	_ bson.Getter = Channel{}
+	_ bson.Setter = (*Channel)(nil)
+)
+
+// Kinds of the bson.Raw values accepted by the SetBSON methods of channels
+// and bases, from the BSON specification.
+const (
+	BSONKindString = 0x02
+	BSONKindNull   = 0x0A
+)
+
+// bsonChannel is the BSON document of a channel. It holds the cleaned
+// channel, the default track "latest" is omitted, as in the channel of a
+// stored base and in the SQL form of a channel.
+type bsonChannel struct {
+	Name   string `bson:"name,omitempty"`
+	Track  string `bson:"track,omitempty"`
+	Risk   string `bson:"risk,omitempty"`
+	Branch string `bson:"branch,omitempty"`
+}
+
+// GetBSON implements bson.Getter. The channel is stored as a compact
+// canonical document, e.g. {track: "2.0", risk: "stable"} or {risk: "stable"}
+// for the latest track. The empty channel is stored as null.
+func (c Channel) GetBSON() (interface{}, error) {
+	if c == Empty {
+		return nil, nil
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	c = c.Clean()
+	return bsonChannel{
+		Track:  c.Track,
+		Risk:   string(c.Risk),
+		Branch: c.Branch,
+	}, nil
+}
+
+// SetBSON implements bson.Setter. Besides the documents written by GetBSON,
+// it accepts documents with an explicit "latest" track, legacy documents
+// holding the JSON fields of Channel and channel strings. The decoded
+// channel is normalized.
+func (c *Channel) SetBSON(raw bson.Raw) error {
+	var (
+		ch  Channel
+		err error
+	)
+	switch raw.Kind {
+	case BSONKindNull:
+		return bson.SetZero
+	case BSONKindString:
+		var s string
+		if err := raw.Unmarshal(&s); err != nil {
+			return err
+		}
+		ch, err = Parse(s)
+	default:
+		var doc bsonChannel
+		if err := raw.Unmarshal(&doc); err != nil {
+			return fmt.Errorf("invalid channel document: %v", err)
+		}
+		ch = Channel{
+			Name:   doc.Name,
+			Track:  doc.Track,
+			Risk:   Risk(doc.Risk),
+			Branch: doc.Branch,
+		}
+		if ch.Track == "" && ch.Risk == "" && ch.Branch == "" && ch.Name != "" {
+			ch, err = Parse(ch.Name)
+		} else {
+			ch, err = ch.ValidatedClean()
+		}
+	}
+	if err != nil {
+		return err
+	}
+	*c = ch
+	return nil
+}
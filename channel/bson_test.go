@@ -0,0 +1,90 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package channel_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/systems/channel"
+)
+
+type bsonSuite struct{}
+
+var _ = gc.Suite(&bsonSuite{})
+
+type channelDoc struct {
+	Channel channel.Channel `bson:"channel"`
+}
+
+func (s *bsonSuite) TestMarshal(c *gc.C) {
+	tests := []struct {
+		channel string
+		doc     bson.M
+	}{{
+		channel: "stable",
+		doc:     bson.M{"risk": "stable"},
+	}, {
+		channel: "latest/edge/fix",
+		doc:     bson.M{"risk": "edge", "branch": "fix"},
+	}, {
+		channel: "2.0/edge/fix",
+		doc:     bson.M{"track": "2.0", "risk": "edge", "branch": "fix"},
+	}}
+	for i, test := range tests {
+		c.Logf("test %d: %s", i, test.channel)
+		data, err := bson.Marshal(channelDoc{Channel: channel.MustParse(test.channel)})
+		c.Assert(err, jc.ErrorIsNil)
+		var doc bson.M
+		c.Assert(bson.Unmarshal(data, &doc), jc.ErrorIsNil)
+		c.Check(doc, jc.DeepEquals, bson.M{"channel": test.doc})
+
+		var decoded channelDoc
+		c.Assert(bson.Unmarshal(data, &decoded), jc.ErrorIsNil)
+		c.Check(decoded.Channel, jc.DeepEquals, channel.MustParse(test.channel))
+	}
+}
+
+func (s *bsonSuite) TestUnmarshal(c *gc.C) {
+	tests := []struct {
+		value   interface{}
+		channel channel.Channel
+		err     string
+	}{{
+		value:   "2.0/beta",
+		channel: channel.MustParse("2.0/beta"),
+	}, {
+		value:   bson.M{"track": "latest", "risk": "stable"},
+		channel: channel.MustParse("stable"),
+	}, {
+		value:   bson.M{"name": "candidate", "track": "", "risk": "candidate"},
+		channel: channel.MustParse("candidate"),
+	}, {
+		value:   bson.M{"name": "3.0/edge"},
+		channel: channel.MustParse("3.0/edge"),
+	}, {
+		value:   nil,
+		channel: channel.Empty,
+	}, {
+		value: bson.M{"risk": "foo"},
+		err:   `invalid risk in channel: "foo"`,
+	}, {
+		value: "a/b/c/d",
+		err:   "channel name has too many components: a/b/c/d",
+	}}
+	for i, test := range tests {
+		c.Logf("test %d: %v", i, test.value)
+		data, err := bson.Marshal(bson.M{"channel": test.value})
+		c.Assert(err, jc.ErrorIsNil)
+		var doc channelDoc
+		err = bson.Unmarshal(data, &doc)
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			continue
+		}
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(doc.Channel, jc.DeepEquals, test.channel)
+	}
+}
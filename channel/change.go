@@ -0,0 +1,136 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package channel
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ChangeKind is a kind of move between two channels.
+type ChangeKind string
+
+// Kinds of channel changes.
+const (
+	ChangeSame           ChangeKind = "same"
+	ChangeRiskTightened  ChangeKind = "risk-tightened"
+	ChangeRiskLoosened   ChangeKind = "risk-loosened"
+	ChangeTrackSwitch    ChangeKind = "track-switch"
+	ChangeBranchEntered  ChangeKind = "branch-entered"
+	ChangeBranchLeft     ChangeKind = "branch-left"
+	ChangeBranchSwitch   ChangeKind = "branch-switch"
+	ChangePinnedViolated ChangeKind = "pinned-track-violation"
+)
+
+// Change describes a refresh from one channel to another.
+type Change struct {
+	// From is the channel refreshed from.
+	From Channel
+	// To is the channel refreshed to, once resolved against From.
+	To Channel
+	// Kinds holds every kind of move the change is made of.
+	Kinds []ChangeKind
+	// Summary is a human readable description of the change.
+	Summary string
+}
+
+// Has returns true if the change includes the given kind of move.
+func (c Change) Has(kind ChangeKind) bool {
+	for _, k := range c.Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the change summary.
+func (c Change) String() string {
+	return c.Summary
+}
+
+// Classify describes the move from one channel to another. The to channel
+// is resolved against from with Resolve, so a risk/branch-only channel stays
+// on the track of from.
+func Classify(from, to Channel) (Change, error) {
+	resolved, err := Resolve(from.Format(FormatVerbatim), to.Format(FormatVerbatim))
+	if err != nil {
+		return Change{}, err
+	}
+	return classify(from, resolved)
+}
+
+// ClassifyPinned describes the move from one channel to another while track
+// is pinned. The to channel is resolved with ResolvePinned. Moving to a
+// different track is reported as a ChangePinnedViolated change rather than
+// ErrPinnedTrackSwitch.
+func ClassifyPinned(track string, from, to Channel) (Change, error) {
+	resolved, err := ResolvePinned(track, to.Format(FormatVerbatim))
+	if err == ErrPinnedTrackSwitch {
+		change, err := Classify(from, to)
+		if err != nil {
+			return Change{}, err
+		}
+		change.Kinds = append([]ChangeKind{ChangePinnedViolated}, change.Kinds...)
+		change.Summary = fmt.Sprintf("%s: %s (track %q is pinned)", ErrPinnedTrackSwitch, change.Summary, track)
+		return change, nil
+	}
+	if err != nil {
+		return Change{}, err
+	}
+	return classify(from, resolved)
+}
+
+func classify(from Channel, resolved string) (Change, error) {
+	from = from.Clean()
+	to := from
+	if resolved != "" {
+		var err error
+		if to, err = Parse(resolved); err != nil {
+			return Change{}, err
+		}
+	}
+	change := Change{From: from, To: to}
+
+	var details []string
+	add := func(kind ChangeKind, format string, args ...interface{}) {
+		change.Kinds = append(change.Kinds, kind)
+		details = append(details, fmt.Sprintf(format, args...))
+	}
+	if from.Track != to.Track {
+		add(ChangeTrackSwitch, "track switched from %s to %s", fullTrack(from.Track), fullTrack(to.Track))
+	}
+	switch cmp := to.Risk.Compare(from.Risk); {
+	case cmp < 0:
+		add(ChangeRiskTightened, "risk tightened from %s to %s", from.Risk, to.Risk)
+	case cmp > 0:
+		add(ChangeRiskLoosened, "risk loosened from %s to %s", from.Risk, to.Risk)
+	}
+	switch {
+	case from.Branch == to.Branch:
+	case from.Branch == "":
+		add(ChangeBranchEntered, "branch %s entered", to.Branch)
+	case to.Branch == "":
+		add(ChangeBranchLeft, "branch %s left", from.Branch)
+	default:
+		add(ChangeBranchSwitch, "branch switched from %s to %s", from.Branch, to.Branch)
+	}
+
+	if len(change.Kinds) == 0 {
+		change.Kinds = []ChangeKind{ChangeSame}
+		change.Summary = fmt.Sprintf("%s: no change", from.Full())
+		return change, nil
+	}
+	change.Summary = fmt.Sprintf("%s to %s: %s", from.Full(), to.Full(), strings.Join(details, ", "))
+	return change, nil
+}
+
+// fullTrack returns the track of a cleaned channel, inclusive the default
+// track "latest".
+func fullTrack(track string) string {
+	if track == "" {
+		return DefaultTrack
+	}
+	return track
+}
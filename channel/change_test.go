@@ -0,0 +1,73 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package channel_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/systems/channel"
+)
+
+type changeSuite struct{}
+
+var _ = gc.Suite(&changeSuite{})
+
+func verbatim(c *gc.C, s string) channel.Channel {
+	ch, err := channel.ParseVerbatim(s)
+	c.Assert(err, jc.ErrorIsNil)
+	return ch
+}
+
+func (s *changeSuite) TestClassify(c *gc.C) {
+	tests := []struct {
+		from    string
+		to      string
+		kinds   []channel.ChangeKind
+		summary string
+	}{
+		{"stable", "latest/stable", []channel.ChangeKind{channel.ChangeSame}, "latest/stable: no change"},
+		{"2.0/edge", "stable", []channel.ChangeKind{channel.ChangeRiskTightened}, "2.0/edge to 2.0/stable: risk tightened from edge to stable"},
+		{"2.0/stable", "candidate", []channel.ChangeKind{channel.ChangeRiskLoosened}, "2.0/stable to 2.0/candidate: risk loosened from stable to candidate"},
+		{"2.0/stable", "3.0/stable", []channel.ChangeKind{channel.ChangeTrackSwitch}, "2.0/stable to 3.0/stable: track switched from 2.0 to 3.0"},
+		{"edge", "3.0/stable", []channel.ChangeKind{channel.ChangeTrackSwitch, channel.ChangeRiskTightened}, "latest/edge to 3.0/stable: track switched from latest to 3.0, risk tightened from edge to stable"},
+		{"2.0/edge", "edge/fix", []channel.ChangeKind{channel.ChangeBranchEntered}, "2.0/edge to 2.0/edge/fix: branch fix entered"},
+		{"2.0/edge/fix", "2.0/edge", []channel.ChangeKind{channel.ChangeBranchLeft}, "2.0/edge/fix to 2.0/edge: branch fix left"},
+		{"2.0/edge/fix", "beta/other", []channel.ChangeKind{channel.ChangeRiskTightened, channel.ChangeBranchSwitch}, "2.0/edge/fix to 2.0/beta/other: risk tightened from edge to beta, branch switched from fix to other"},
+	}
+	for _, t := range tests {
+		comment := gc.Commentf("%q -> %q", t.from, t.to)
+		change, err := channel.Classify(verbatim(c, t.from), verbatim(c, t.to))
+		c.Assert(err, jc.ErrorIsNil, comment)
+		c.Check(change.Kinds, jc.DeepEquals, t.kinds, comment)
+		c.Check(change.String(), gc.Equals, t.summary, comment)
+		for _, k := range t.kinds {
+			c.Check(change.Has(k), jc.IsTrue, comment)
+		}
+	}
+}
+
+func (s *changeSuite) TestClassifyPinned(c *gc.C) {
+	change, err := channel.ClassifyPinned("2.0", verbatim(c, "2.0/stable"), verbatim(c, "edge"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(change.Kinds, jc.DeepEquals, []channel.ChangeKind{channel.ChangeRiskLoosened})
+	c.Check(change.To, jc.DeepEquals, mustParse(c, "2.0/edge"))
+
+	change, err = channel.ClassifyPinned("2.0", verbatim(c, "2.0/stable"), verbatim(c, "3.0/stable"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(change.Kinds, jc.DeepEquals, []channel.ChangeKind{channel.ChangePinnedViolated, channel.ChangeTrackSwitch})
+	c.Check(change.Summary, gc.Equals, `cannot switch pinned track: 2.0/stable to 3.0/stable: track switched from 2.0 to 3.0 (track "2.0" is pinned)`)
+
+	_, err = channel.ClassifyPinned("2.0/stable", verbatim(c, "2.0/stable"), verbatim(c, "edge"))
+	c.Check(err, gc.ErrorMatches, "invalid pinned track: 2.0/stable")
+}
+
+func (s *changeSuite) TestClassifyAgreesWithResolve(c *gc.C) {
+	from, to := verbatim(c, "2.0/stable"), verbatim(c, "beta/fix")
+	resolved, err := channel.Resolve("2.0/stable", "beta/fix")
+	c.Assert(err, jc.ErrorIsNil)
+	change, err := channel.Classify(from, to)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(change.To.String(), gc.Equals, resolved)
+}
@@ -49,34 +49,17 @@ type Channel struct {
 
 func isSlash(r rune) bool { return r == '/' }
 
-// TODO: currently there's some overlap between the toplevel Full, and
-//       methods Clean, String, and Full. Needs further refactoring.
-
 // Full normalizes the channel string to also include stable when a
 // risk is not found in the original channel string.
 func Full(s string) (string, error) {
-	if s == "" {
-		return "", nil
+	ch, ok := parseLenient(s)
+	if !ok {
+		return "", errors.New("invalid channel")
 	}
-	components := strings.FieldsFunc(s, isSlash)
-	switch len(components) {
-	case 0:
+	if ch == Empty {
 		return "", nil
-	case 1:
-		if channelRisks.Contains(components[0]) {
-			return "latest/" + components[0], nil
-		}
-		return components[0] + "/stable", nil
-	case 2:
-		if channelRisks.Contains(components[0]) {
-			return "latest/" + strings.Join(components, "/"), nil
-		}
-		fallthrough
-	case 3:
-		return strings.Join(components, "/"), nil
-	default:
-		return "", errors.New("invalid channel")
 	}
+	return ch.Format(FormatFull), nil
 }
 
 // ParseVerbatim parses a string representing a store channel.
@@ -152,46 +135,80 @@ func MustParse(s string) Channel {
 
 // Clean returns a Channel with a normalized track, risk and name.
 func (c Channel) Clean() Channel {
-	track := c.Track
-	risk := c.Risk
+	return DefaultContext.Clean(c)
+}
 
-	if track == "latest" {
-		track = ""
-	}
-	if risk == "" {
-		risk = Stable
-	}
+func (c Channel) String() string {
+	return c.Name
+}
 
-	// normalized name
-	name := string(risk)
-	if track != "" {
-		name = track + "/" + name
+// Full returns the full name of the channel, inclusive the default track "latest".
+// Full never panics: if the channel name is missing or malformed, the full
+// name is built from the track, risk and branch instead. Use ValidatedFull
+// to detect malformed channels.
+func (c *Channel) Full() string {
+	if *c == Empty {
+		return ""
 	}
-	if c.Branch != "" {
-		name = name + "/" + c.Branch
+	full, err := Full(c.String())
+	if err != nil || full == "" {
+		return c.Format(FormatFull)
 	}
+	return full
+}
 
-	return Channel{
-		Name:   name,
-		Track:  track,
-		Risk:   risk,
-		Branch: c.Branch,
+// ValidatedFull returns the full name of the channel, inclusive the default
+// track "latest", or an error if the channel is not valid.
+func (c *Channel) ValidatedFull() (string, error) {
+	if err := c.Validate(); err != nil {
+		return "", err
 	}
+	return c.Full(), nil
 }
 
-func (c Channel) String() string {
-	return c.Name
+// ValidatedClean returns a Channel with a normalized track, risk and name, or
+// an error if the channel is not valid.
+func (c Channel) ValidatedClean() (Channel, error) {
+	if err := c.Validate(); err != nil {
+		return Empty, err
+	}
+	return c.Clean(), nil
 }
 
-// Full returns the full name of the channel, inclusive the default track "latest".
-func (c *Channel) Full() string {
-	ch := c.String()
-	full, err := Full(ch)
+// Validate returns an error if the channel is empty or malformed, e.g. when it
+// was built by hand or unmarshalled from persisted data. Both verbatim and
+// normalized channels are considered valid.
+func (c Channel) Validate() error {
+	if c == Empty {
+		return errors.New("channel cannot be empty")
+	}
+	if c.Risk != "" && !channelRisks.Contains(string(c.Risk)) {
+		return fmt.Errorf("invalid risk in channel: %q", string(c.Risk))
+	}
+	if strings.Contains(c.Track, "/") || channelRisks.Contains(c.Track) {
+		return fmt.Errorf("invalid track in channel: %q", c.Track)
+	}
+	if strings.Contains(c.Branch, "/") {
+		return fmt.Errorf("invalid branch in channel: %q", c.Branch)
+	}
+	if c.Branch != "" && c.Risk == "" {
+		return fmt.Errorf("channel branch %q requires a risk", c.Branch)
+	}
+	if c.Name == "" {
+		if c.Track == "" && c.Risk == "" {
+			return errors.New("channel has no track or risk")
+		}
+		return nil
+	}
+	named, err := ParseVerbatim(c.Name)
 	if err != nil {
-		// impossible
-		panic("channel.String() returned a malformed channel: " + ch)
+		return fmt.Errorf("invalid channel name: %v", err)
 	}
-	return full
+	if named.Clean() != (Channel{Track: c.Track, Risk: c.Risk, Branch: c.Branch}).Clean() {
+		return fmt.Errorf("channel name %q does not match track %q, risk %q and branch %q",
+			c.Name, c.Track, string(c.Risk), c.Branch)
+	}
+	return nil
 }
 
 // VerbatimTrackOnly returns whether the channel represents a track only.
@@ -245,25 +262,28 @@ func (c *Channel) Match(c1 *Channel) Match {
 }
 
 // Resolve resolves newChannel wrt channel, this means if newChannel
-// is risk/branch only it will preserve the track of channel. It
-// assumes that if both are not empty, channel is parseable.
+// is risk/branch only it will preserve the track of channel. Both channels
+// are validated, see ResolveChannel.
 func Resolve(channel, newChannel string) (string, error) {
 	if newChannel == "" {
 		return channel, nil
 	}
-	if channel == "" {
-		return newChannel, nil
+	var ch Channel
+	if channel != "" {
+		var err error
+		if ch, err = ParseVerbatim(channel); err != nil {
+			return "", err
+		}
 	}
-	ch, err := ParseVerbatim(channel)
+	newCh, err := ParseVerbatim(newChannel)
 	if err != nil {
 		return "", err
 	}
-	p := strings.Split(newChannel, "/")
-	if channelRisks.Contains(p[0]) && ch.Track != "" {
-		// risk/branch inherits the track if any
-		return ch.Track + "/" + newChannel, nil
+	resolved, err := ResolveChannel(ch, newCh)
+	if err != nil {
+		return "", err
 	}
-	return newChannel, nil
+	return resolved.Channel.Format(FormatVerbatim), nil
 }
 
 // ErrPinnedTrackSwitch is returned from ResolvePinned when a track is pinned
@@ -272,27 +292,25 @@ var ErrPinnedTrackSwitch = errors.New("cannot switch pinned track")
 
 // ResolvePinned resolves newChannel wrt a pinned track, newChannel
 // can only be risk/branch-only or have the same track, otherwise
-// ErrPinnedTrackSwitch is returned.
+// ErrPinnedTrackSwitch is returned. See ResolvePinnedChannel.
 func ResolvePinned(track, newChannel string) (string, error) {
-	if track == "" {
-		return newChannel, nil
-	}
-	ch, err := ParseVerbatim(track)
-	if err != nil || !ch.VerbatimTrackOnly() {
-		return "", fmt.Errorf("invalid pinned track: %s", track)
-	}
-	if newChannel == "" {
-		return track, nil
+	var pinned Channel
+	if track != "" {
+		var err error
+		if pinned, err = ParseVerbatim(track); err != nil || !pinned.VerbatimTrackOnly() {
+			return "", fmt.Errorf("invalid pinned track: %s", track)
+		}
 	}
-	trackPrefix := ch.Track + "/"
-	p := strings.Split(newChannel, "/")
-	if channelRisks.Contains(p[0]) && ch.Track != "" {
-		// risk/branch inherits the track if any
-		return trackPrefix + newChannel, nil
+	var newCh Channel
+	if newChannel != "" {
+		var err error
+		if newCh, err = ParseVerbatim(newChannel); err != nil {
+			return "", err
+		}
 	}
-	if newChannel != track && !strings.HasPrefix(newChannel, trackPrefix) {
-		// the track is pinned
-		return "", ErrPinnedTrackSwitch
+	resolved, err := ResolvePinnedChannel(pinned, newCh)
+	if err != nil {
+		return "", err
 	}
-	return newChannel, nil
+	return resolved.Channel.Format(FormatVerbatim), nil
 }
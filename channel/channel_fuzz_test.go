@@ -0,0 +1,39 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+//go:build go1.18
+// +build go1.18
+
+package channel_test
+
+import (
+	stdtesting "testing"
+
+	"github.com/juju/systems/channel"
+)
+
+// FuzzParse checks that Parse→String→Parse is a fixed point for every
+// channel string the parser accepts.
+func FuzzParse(f *stdtesting.F) {
+	for _, seed := range []string{
+		"stable", "latest/stable", "1.0", "1.0/edge", "1.0/beta/foo", "candidate/foo", "latest/edge/foo",
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *stdtesting.T, str string) {
+		ch, err := channel.Parse(str)
+		if err != nil {
+			return
+		}
+		if ch != ch.Clean() {
+			t.Fatalf("Parse(%q) returned unclean channel %#v", str, ch)
+		}
+		again, err := channel.Parse(ch.String())
+		if err != nil {
+			t.Fatalf("Parse(%q) failed to parse %q: %v", str, ch.String(), err)
+		}
+		if again != ch {
+			t.Fatalf("Parse(%q) = %#v, reparsed %q as %#v", str, ch, ch.String(), again)
+		}
+	})
+}
@@ -351,3 +351,62 @@ func (s *storeChannelSuite) TestResolvePinned(c *gc.C) {
 		}
 	}
 }
+
+func (s *storeChannelSuite) TestValidate(c *gc.C) {
+	for _, str := range []string{"stable", "latest/stable", "1.0", "1.0/beta/foo", "candidate/foo"} {
+		ch, err := channel.ParseVerbatim(str)
+		c.Assert(err, gc.IsNil)
+		c.Check(ch.Validate(), gc.IsNil, gc.Commentf("%q", str))
+		c.Check(mustParse(c, str).Validate(), gc.IsNil, gc.Commentf("%q", str))
+	}
+
+	tests := []struct {
+		channel channel.Channel
+		err     string
+	}{
+		{channel.Channel{}, "channel cannot be empty"},
+		{channel.Channel{Branch: "foo"}, `channel branch "foo" requires a risk`},
+		{channel.Channel{Track: "1.0", Risk: "bogus"}, `invalid risk in channel: "bogus"`},
+		{channel.Channel{Track: "1.0/2.0", Risk: channel.Stable}, `invalid track in channel: "1.0/2.0"`},
+		{channel.Channel{Track: "edge", Risk: channel.Stable}, `invalid track in channel: "edge"`},
+		{channel.Channel{Risk: channel.Stable, Branch: "a/b"}, `invalid branch in channel: "a/b"`},
+		{channel.Channel{Name: "edge"}, `channel name "edge" does not match track "", risk "" and branch ""`},
+		{channel.Channel{Name: "a/b/c/d", Track: "a", Risk: channel.Stable}, "invalid channel name: channel name has too many components: a/b/c/d"},
+		{channel.Channel{Name: "2.0/edge", Track: "1.0", Risk: channel.Edge}, `channel name "2.0/edge" does not match track "1.0", risk "edge" and branch ""`},
+	}
+	for _, t := range tests {
+		c.Check(t.channel.Validate(), gc.ErrorMatches, t.err, gc.Commentf("%#v", t.channel))
+	}
+}
+
+func (s *storeChannelSuite) TestChannelFullMalformed(c *gc.C) {
+	tests := []struct {
+		channel channel.Channel
+		full    string
+		err     string
+	}{
+		{channel.Channel{Name: "a/b/c/d", Track: "a", Risk: channel.Edge, Branch: "d"}, "a/edge/d", "invalid channel name: .*"},
+		{channel.Channel{Risk: channel.Beta, Branch: "foo"}, "latest/beta/foo", ""},
+		{channel.Channel{}, "", "channel cannot be empty"},
+	}
+	for _, t := range tests {
+		ch := t.channel
+		c.Check(ch.Full(), gc.Equals, t.full)
+		full, err := ch.ValidatedFull()
+		if t.err != "" {
+			c.Check(err, gc.ErrorMatches, t.err)
+			continue
+		}
+		c.Check(err, gc.IsNil)
+		c.Check(full, gc.Equals, t.full)
+	}
+}
+
+func (s *storeChannelSuite) TestValidatedClean(c *gc.C) {
+	ch, err := channel.Channel{Track: "latest", Risk: channel.Edge}.ValidatedClean()
+	c.Assert(err, gc.IsNil)
+	c.Check(ch, jc.DeepEquals, mustParse(c, "edge"))
+
+	_, err = channel.Channel{Track: "1.0", Risk: "bogus"}.ValidatedClean()
+	c.Check(err, gc.ErrorMatches, `invalid risk in channel: "bogus"`)
+}
@@ -0,0 +1,176 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package channel
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/clock"
+)
+
+// ErrNoRelease is returned by ChannelMap.Resolve when no release can serve
+// the requested channel.
+var ErrNoRelease = errors.New("no release found")
+
+// Release is an entry of a published channel map.
+type Release struct {
+	Channel      Channel
+	Revision     int
+	Closed       bool
+	Architecture string
+	// Base is the base of the release in the "os/track" form used by
+	// the stores, e.g. "ubuntu/20.04".
+	Base string
+
+	// CreatedAt is when a branch was created, it is only relevant to
+	// releases on a branch.
+	CreatedAt time.Time
+	// ExpiresAt is when a branch expires. When zero, the branch expires
+	// DefaultBranchLifetime after CreatedAt.
+	ExpiresAt time.Time
+
+	// Progressive, if set, describes a new revision being progressively
+	// released to the channel.
+	Progressive *Progressive
+}
+
+// ChannelMap holds the releases published to the channels of a store entity.
+type ChannelMap []Release
+
+// ResolveOptions holds the options used to resolve a channel in a channel map.
+type ResolveOptions struct {
+	// Architecture, if set, only considers releases for that architecture.
+	Architecture string
+	// Base, if set, only considers releases for that base. It must be in
+	// the "os/track" form of Release.Base, e.g. "ubuntu/20.04".
+	Base string
+	// AllowLessStable permits falling through to a less stable risk when
+	// neither the requested risk nor any more stable risk is open.
+	AllowLessStable bool
+	// Clock, if set, is used to treat expired branches as closed.
+	Clock clock.Clock
+	// CohortKey, if set, selects between the current and the new revision
+	// of progressive releases. The resolved release revision is set to the
+	// revision served to the cohort, and its progressive release is cleared.
+	CohortKey string
+}
+
+// Resolution is the result of resolving a channel in a channel map.
+type Resolution struct {
+	// Release is the release serving the requested channel.
+	Release Release
+	// Path explains, step by step, how the release was found.
+	Path []string
+}
+
+// String returns the resolution path as a single line.
+func (r Resolution) String() string {
+	return strings.Join(r.Path, ", ")
+}
+
+// Resolve finds the release serving the requested channel, following the
+// store fall-through semantics: a branch that is closed or missing falls back
+// to its risk, and a risk that is closed or missing is served by the next
+// more stable open risk of the same track. Fall-through candidates are
+// selected with Channel.Match, so they must match on both track and risk.
+func (m ChannelMap) Resolve(requested Channel, opts ResolveOptions) (Resolution, error) {
+	requested = requested.Clean()
+	res := Resolution{}
+
+	if requested.Branch != "" {
+		if res.try(m.filter(opts, func(r Release) bool {
+			return r.Channel.Clean() == requested
+		}), requested, opts) {
+			return res, nil
+		}
+		res.step("falling back to %s", requested.Risk)
+		requested = Channel{Track: requested.Track, Risk: requested.Risk}.Clean()
+	}
+
+	// Releases on the same track, at the requested risk or more stable.
+	candidates := m.filter(opts, func(r Release) bool {
+		ch := r.Channel.Clean()
+		match := requested.Match(&ch)
+		return match.Track && match.Risk
+	})
+	for risk := requested.Risk; risk != Unknown; risk = risk.Next() {
+		if res.try(candidates, Channel{Track: requested.Track, Risk: risk}.Clean(), opts) {
+			return res, nil
+		}
+	}
+
+	if opts.AllowLessStable {
+		// Releases on the same track, less stable than requested.
+		candidates = m.filter(opts, func(r Release) bool {
+			ch := r.Channel.Clean()
+			match := requested.Match(&ch)
+			return match.Track && !match.Risk
+		})
+		for risk := requested.Risk.Prev(); risk != Unknown; risk = risk.Prev() {
+			if res.try(candidates, Channel{Track: requested.Track, Risk: risk}.Clean(), opts) {
+				res.step("less stable than requested %s, permitted", requested.Risk)
+				return res, nil
+			}
+		}
+	}
+	return res, fmt.Errorf("channel %s: %w", requested.Full(), ErrNoRelease)
+}
+
+// try sets the resolution release to the open release published to ch among
+// the candidates, recording the outcome in the resolution path. It returns
+// false if there is no such open release. Expired branches are closed.
+func (res *Resolution) try(candidates ChannelMap, ch Channel, opts ResolveOptions) bool {
+	for _, r := range candidates {
+		if r.Channel.Clean() != ch {
+			continue
+		}
+		if r.Closed {
+			res.step("%s is closed", ch.Full())
+			return false
+		}
+		if opts.Clock != nil && r.Expired(opts.Clock.Now()) {
+			expiresAt, _ := r.BranchExpiresAt()
+			res.step("%s expired at %s", ch.Full(), expiresAt.UTC().Format(time.RFC3339))
+			return false
+		}
+		res.step("%s is open at revision %d", ch.Full(), r.Revision)
+		if p := r.Progressive; p != nil && opts.CohortKey != "" {
+			if p.Includes(opts.CohortKey) {
+				res.step("cohort in progressive release of revision %d to %g%%", p.Revision, p.Percentage)
+			} else {
+				res.step("cohort not in progressive release of revision %d to %g%%", p.Revision, p.Percentage)
+			}
+			r.Revision = r.RevisionFor(opts.CohortKey)
+			r.Progressive = nil
+		}
+		res.Release = r
+		return true
+	}
+	res.step("%s has no release", ch.Full())
+	return false
+}
+
+func (res *Resolution) step(format string, args ...interface{}) {
+	res.Path = append(res.Path, fmt.Sprintf(format, args...))
+}
+
+// filter returns the releases matching the options and the predicate.
+func (m ChannelMap) filter(opts ResolveOptions, pred func(Release) bool) ChannelMap {
+	var res ChannelMap
+	for _, r := range m {
+		if opts.Architecture != "" && r.Architecture != opts.Architecture {
+			continue
+		}
+		if opts.Base != "" && r.Base != opts.Base {
+			continue
+		}
+		if pred(r) {
+			res = append(res, r)
+		}
+	}
+	return res
+}
@@ -0,0 +1,116 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package channel_test
+
+import (
+	"errors"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/systems/channel"
+)
+
+type channelMapSuite struct{}
+
+var _ = gc.Suite(&channelMapSuite{})
+
+func release(ch string, revision int) channel.Release {
+	return channel.Release{
+		Channel:      channel.MustParse(ch),
+		Revision:     revision,
+		Architecture: "amd64",
+		Base:         "ubuntu/20.04",
+	}
+}
+
+func closed(ch string) channel.Release {
+	r := release(ch, 0)
+	r.Closed = true
+	return r
+}
+
+var testChannelMap = channel.ChannelMap{
+	release("latest/stable", 10),
+	release("latest/candidate", 11),
+	release("latest/beta", 12),
+	closed("latest/edge"),
+	release("latest/edge/fix", 13),
+	closed("latest/beta/old"),
+	closed("2.0/stable"),
+	release("2.0/candidate", 20),
+	release("2.0/edge", 21),
+	release("3.0/stable", 30),
+	{Channel: channel.MustParse("3.0/stable"), Revision: 31, Architecture: "arm64", Base: "ubuntu/20.04"},
+}
+
+func (s *channelMapSuite) TestResolve(c *gc.C) {
+	tests := []struct {
+		requested string
+		opts      channel.ResolveOptions
+		revision  int
+		path      string
+		err       string
+	}{{
+		requested: "stable",
+		revision:  10,
+		path:      "latest/stable is open at revision 10",
+	}, {
+		requested: "latest/edge",
+		revision:  12,
+		path:      "latest/edge is closed, latest/beta is open at revision 12",
+	}, {
+		requested: "edge/fix",
+		revision:  13,
+		path:      "latest/edge/fix is open at revision 13",
+	}, {
+		requested: "beta/old",
+		revision:  12,
+		path:      "latest/beta/old is closed, falling back to beta, latest/beta is open at revision 12",
+	}, {
+		requested: "candidate/missing",
+		revision:  11,
+		path:      "latest/candidate/missing has no release, falling back to candidate, latest/candidate is open at revision 11",
+	}, {
+		requested: "2.0/stable",
+		path:      "2.0/stable is closed",
+		err:       "channel 2.0/stable: no release found",
+	}, {
+		requested: "2.0/stable",
+		opts:      channel.ResolveOptions{AllowLessStable: true},
+		revision:  20,
+		path:      "2.0/stable is closed, 2.0/candidate is open at revision 20, less stable than requested stable, permitted",
+	}, {
+		requested: "2.0/beta",
+		revision:  20,
+		path:      "2.0/beta has no release, 2.0/candidate is open at revision 20",
+	}, {
+		requested: "3.0/edge",
+		opts:      channel.ResolveOptions{Architecture: "arm64"},
+		revision:  31,
+		path:      "3.0/edge has no release, 3.0/beta has no release, 3.0/candidate has no release, 3.0/stable is open at revision 31",
+	}, {
+		requested: "3.0/stable",
+		opts:      channel.ResolveOptions{Base: "ubuntu/22.04"},
+		path:      "3.0/stable has no release",
+		err:       "channel 3.0/stable: no release found",
+	}, {
+		requested: "4.0/edge",
+		opts:      channel.ResolveOptions{AllowLessStable: true},
+		path:      "4.0/edge has no release, 4.0/beta has no release, 4.0/candidate has no release, 4.0/stable has no release",
+		err:       "channel 4.0/edge: no release found",
+	}}
+	for i, t := range tests {
+		comment := gc.Commentf("test %d: %s", i, t.requested)
+		res, err := testChannelMap.Resolve(channel.MustParse(t.requested), t.opts)
+		c.Check(res.String(), gc.Equals, t.path, comment)
+		if t.err != "" {
+			c.Check(err, gc.ErrorMatches, t.err, comment)
+			c.Check(errors.Is(err, channel.ErrNoRelease), jc.IsTrue, comment)
+			continue
+		}
+		c.Assert(err, jc.ErrorIsNil, comment)
+		c.Check(res.Release.Revision, gc.Equals, t.revision, comment)
+	}
+}
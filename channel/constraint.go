@@ -0,0 +1,302 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package channel
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// CompareTracks compares two track names, understanding dotted versions:
+// "2.10" is greater than "2.9". Numeric parts are compared numerically and
+// ordered before non numeric parts, which are compared lexically. The empty
+// track stands for "latest". The result is negative if a < b, zero if they
+// are equal and positive if a > b.
+func CompareTracks(a, b string) int {
+	a, b = fullTrack(a), fullTrack(b)
+	ap, bp := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(ap) && i < len(bp); i++ {
+		aNum, bNum := isNumeric(ap[i]), isNumeric(bp[i])
+		switch {
+		case aNum && bNum:
+			if c := compareNumeric(ap[i], bp[i]); c != 0 {
+				return c
+			}
+		case aNum:
+			return -1
+		case bNum:
+			return 1
+		default:
+			if c := strings.Compare(ap[i], bp[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return len(ap) - len(bp)
+}
+
+// isVersion returns true if the track is a dotted version, such as "2.9".
+func isVersion(track string) bool {
+	for _, part := range strings.Split(track, ".") {
+		if !isNumeric(part) {
+			return false
+		}
+	}
+	return true
+}
+
+// isNumeric returns true if s is a non empty string of decimal digits.
+func isNumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// compareNumeric compares two strings of decimal digits by value, without
+// converting them, so that they cannot overflow.
+func compareNumeric(a, b string) int {
+	a, b = strings.TrimLeft(a, "0"), strings.TrimLeft(b, "0")
+	if len(a) != len(b) {
+		if len(a) < len(b) {
+			return -1
+		}
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// Constraint is a channel constraint expression used by tracking policies,
+// of the form "track[/risk[/branch]]". For example:
+//
+//  3.*/>=candidate      any 3.x track, at candidate or safer
+//  2.0/stable|candidate track 2.0, at stable or candidate
+//  >=2.9/stable         track 2.9 or any later version, at stable
+//  */edge/*             any track at edge, on any branch
+//
+// The track is "*" for any track, a glob pattern, an exact track, or a
+// comparison operator (=, >, >=, <, <=) followed by a dotted version, which
+// only matches tracks that are dotted versions too. The
+// risk is "*" for any risk, a list of risks separated by "|", or a
+// comparison operator followed by a risk, where ">=" means "as stable or
+// more stable than". When the risk is omitted any risk matches. As for
+// channels, a constraint starting with a risk applies to the "latest" track.
+// The branch is "*" for any branch or an exact branch; when omitted only
+// channels that are not on a branch match.
+type Constraint struct {
+	expr string
+
+	trackOp      string
+	trackPattern string
+
+	riskOp string
+	risks  []Risk
+
+	branch string
+}
+
+// ParseConstraint parses a channel constraint expression.
+func ParseConstraint(s string) (Constraint, error) {
+	fail := func(format string, args ...interface{}) (Constraint, error) {
+		return Constraint{}, fmt.Errorf("invalid channel constraint %q: %s", s, fmt.Sprintf(format, args...))
+	}
+	if s == "" {
+		return fail("cannot be empty")
+	}
+	parts := strings.Split(s, "/")
+	if len(parts) > 3 {
+		return fail("too many components")
+	}
+	if isRiskSpec(parts[0]) {
+		parts = append([]string{DefaultTrack}, parts...)
+		if len(parts) > 3 {
+			return fail("too many components")
+		}
+	}
+
+	c := Constraint{expr: s}
+	c.trackOp, c.trackPattern = splitOp(parts[0])
+	switch {
+	case c.trackPattern == "":
+		return fail("missing track")
+	case c.trackOp != "" && isGlob(c.trackPattern):
+		return fail("cannot compare track with pattern %q", c.trackPattern)
+	case c.trackOp != "" && !isVersion(c.trackPattern):
+		return fail("cannot compare track with non version %q", c.trackPattern)
+	case c.trackOp == "" && isGlob(c.trackPattern):
+		if _, err := path.Match(c.trackPattern, ""); err != nil {
+			return fail("bad track pattern: %v", err)
+		}
+	}
+
+	risk := "*"
+	if len(parts) > 1 {
+		risk = parts[1]
+	}
+	if risk != "*" {
+		op, list := splitOp(risk)
+		if op != "" && strings.Contains(list, "|") {
+			return fail("cannot compare risk with a list")
+		}
+		c.riskOp = op
+		for _, r := range strings.Split(list, "|") {
+			parsed, err := ParseRisk(r)
+			if err != nil {
+				return fail("%v", err)
+			}
+			c.risks = append(c.risks, parsed)
+		}
+	}
+
+	if len(parts) > 2 {
+		if parts[2] == "" {
+			return fail("missing branch")
+		}
+		c.branch = parts[2]
+	}
+	return c, nil
+}
+
+// MustParseConstraint parses a channel constraint expression or panics.
+func MustParseConstraint(s string) Constraint {
+	c, err := ParseConstraint(s)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// String returns the constraint expression.
+func (c Constraint) String() string {
+	return c.expr
+}
+
+// Matches returns true if the channel satisfies the constraint.
+func (c Constraint) Matches(ch Channel) bool {
+	ch = ch.Clean()
+	return c.matchesTrack(ch.Track) && c.matchesRisk(ch.Risk) && c.matchesBranch(ch.Branch)
+}
+
+// Pick returns the candidate that best satisfies the constraint: among the
+// matching candidates, the one on the highest track version, then with the
+// most stable risk, then with the lowest name. Tracks that are dotted
+// versions are preferred to the others, such as "latest". False is returned
+// if no candidate matches.
+func (c Constraint) Pick(candidates []Channel) (Channel, bool) {
+	var matching []Channel
+	for _, ch := range candidates {
+		if c.Matches(ch) {
+			matching = append(matching, ch)
+		}
+	}
+	if len(matching) == 0 {
+		return Empty, false
+	}
+	sort.SliceStable(matching, func(i, j int) bool {
+		a, b := matching[i].Clean(), matching[j].Clean()
+		if av, bv := isVersion(fullTrack(a.Track)), isVersion(fullTrack(b.Track)); av != bv {
+			return av
+		}
+		if cmp := CompareTracks(a.Track, b.Track); cmp != 0 {
+			return cmp > 0
+		}
+		if cmp := a.Risk.Compare(b.Risk); cmp != 0 {
+			return cmp < 0
+		}
+		return a.Name < b.Name
+	})
+	return matching[0], true
+}
+
+func (c Constraint) matchesTrack(track string) bool {
+	track = fullTrack(track)
+	pattern := c.trackPattern
+	switch {
+	case c.trackOp != "":
+		return isVersion(track) && compareOp(c.trackOp, CompareTracks(track, pattern))
+	case pattern == "*":
+		return true
+	case isGlob(pattern):
+		ok, _ := path.Match(pattern, track)
+		return ok
+	default:
+		return track == pattern
+	}
+}
+
+func (c Constraint) matchesRisk(risk Risk) bool {
+	if c.risks == nil {
+		return true
+	}
+	if c.riskOp != "" {
+		// Risks are ordered by stability, a more stable risk is greater.
+		return compareOp(c.riskOp, c.risks[0].Level()-risk.Level())
+	}
+	for _, r := range c.risks {
+		if r == risk {
+			return true
+		}
+	}
+	return false
+}
+
+func (c Constraint) matchesBranch(branch string) bool {
+	switch c.branch {
+	case "":
+		return branch == ""
+	case "*":
+		return true
+	default:
+		return branch == c.branch
+	}
+}
+
+// isGlob returns true if s is a glob pattern rather than an exact track.
+func isGlob(s string) bool {
+	return strings.ContainsAny(s, "*?[")
+}
+
+// isRiskSpec returns true if s can only be a risk specification.
+func isRiskSpec(s string) bool {
+	_, value := splitOp(s)
+	for _, r := range strings.Split(value, "|") {
+		if !channelRisks.Contains(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// splitOp splits a leading comparison operator from s.
+func splitOp(s string) (string, string) {
+	for _, op := range []string{">=", "<=", ">", "<", "="} {
+		if strings.HasPrefix(s, op) {
+			return op, s[len(op):]
+		}
+	}
+	return "", s
+}
+
+// compareOp applies the comparison operator to the result of a comparison.
+func compareOp(op string, cmp int) bool {
+	switch op {
+	case ">=":
+		return cmp >= 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case "<":
+		return cmp < 0
+	default:
+		return cmp == 0
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package channel_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/systems/channel"
+)
+
+type constraintSuite struct{}
+
+var _ = gc.Suite(&constraintSuite{})
+
+func (s *constraintSuite) TestCompareTracks(c *gc.C) {
+	tests := []struct {
+		a, b string
+		cmp  int
+	}{
+		{"2.9", "2.10", -1},
+		{"2.10", "2.9", 1},
+		{"3", "3.0", -1},
+		{"3.0", "3.0", 0},
+		{"1.0", "latest", -1},
+		{"", "latest", 0},
+		{"v2", "v10", 1},
+		{"10", "9", 1},
+		{"1.0", "1.00", 0},
+		{"99999999999999999999", "1", 1},
+		{"1", "99999999999999999999", -1},
+		{"2.99999999999999999999", "2.99999999999999999998", 1},
+	}
+	for _, t := range tests {
+		cmp := channel.CompareTracks(t.a, t.b)
+		comment := gc.Commentf("%q vs %q", t.a, t.b)
+		switch {
+		case t.cmp < 0:
+			c.Check(cmp < 0, jc.IsTrue, comment)
+		case t.cmp > 0:
+			c.Check(cmp > 0, jc.IsTrue, comment)
+		default:
+			c.Check(cmp, gc.Equals, 0, comment)
+		}
+	}
+}
+
+func (s *constraintSuite) TestMatches(c *gc.C) {
+	tests := []struct {
+		constraint string
+		matching   []string
+		rejected   []string
+	}{{
+		constraint: "3.*/>=candidate",
+		matching:   []string{"3.0/stable", "3.1/candidate", "3.10/stable"},
+		rejected:   []string{"3.1/beta", "2.9/stable", "3/stable", "3.1/stable/fix"},
+	}, {
+		constraint: "2.0/stable|candidate",
+		matching:   []string{"2.0/stable", "2.0/candidate", "2.0"},
+		rejected:   []string{"2.0/beta", "2.1/stable"},
+	}, {
+		constraint: "2.0/>=beta",
+		matching:   []string{"2.0/stable", "2.0/beta"},
+		rejected:   []string{"2.0/edge"},
+	}, {
+		constraint: "2.0/<candidate",
+		matching:   []string{"2.0/beta", "2.0/edge"},
+		rejected:   []string{"2.0/candidate", "2.0/stable"},
+	}, {
+		constraint: ">=2.9/stable",
+		matching:   []string{"2.9/stable", "2.10/stable", "3.0/stable"},
+		rejected:   []string{"2.8/stable", "2.9/edge", "latest/stable", "stable", "v3/stable", "insiders/stable"},
+	}, {
+		constraint: "<3/stable",
+		matching:   []string{"2.9/stable", "2/stable"},
+		rejected:   []string{"3/stable", "latest/stable", "v2/stable"},
+	}, {
+		constraint: "*/edge/*",
+		matching:   []string{"edge", "2.0/edge/fix", "3.0/edge"},
+		rejected:   []string{"2.0/beta/fix"},
+	}, {
+		constraint: "stable",
+		matching:   []string{"stable", "latest/stable"},
+		rejected:   []string{"2.0/stable", "edge"},
+	}, {
+		constraint: ">=candidate",
+		matching:   []string{"stable", "candidate"},
+		rejected:   []string{"beta", "2.0/stable"},
+	}, {
+		constraint: "2.0",
+		matching:   []string{"2.0/stable", "2.0/edge"},
+		rejected:   []string{"2.0/edge/fix", "stable"},
+	}, {
+		constraint: "2.0/edge/fix",
+		matching:   []string{"2.0/edge/fix"},
+		rejected:   []string{"2.0/edge", "2.0/edge/other"},
+	}}
+	for _, t := range tests {
+		constraint, err := channel.ParseConstraint(t.constraint)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(constraint.String(), gc.Equals, t.constraint)
+		for _, str := range t.matching {
+			c.Check(constraint.Matches(mustParse(c, str)), jc.IsTrue, gc.Commentf("%q should match %q", t.constraint, str))
+		}
+		for _, str := range t.rejected {
+			c.Check(constraint.Matches(mustParse(c, str)), jc.IsFalse, gc.Commentf("%q should not match %q", t.constraint, str))
+		}
+	}
+}
+
+func (s *constraintSuite) TestParseErrors(c *gc.C) {
+	tests := []struct {
+		constraint string
+		err        string
+	}{
+		{"", `invalid channel constraint "": cannot be empty`},
+		{"a/b/c/d", `invalid channel constraint "a/b/c/d": too many components`},
+		{"stable/a/b", `invalid channel constraint "stable/a/b": too many components`},
+		{">=", `invalid channel constraint ">=": missing track`},
+		{">=3.*", `invalid channel constraint ">=3.\*": cannot compare track with pattern "3.\*"`},
+		{"3.[/stable", `invalid channel constraint "3.\[/stable": bad track pattern: .*`},
+		{"2.0/bogus", `invalid channel constraint "2.0/bogus": invalid risk: "bogus"`},
+		{"2.0/>=stable|edge", `invalid channel constraint "2.0/>=stable\|edge": cannot compare risk with a list`},
+		{"2.0/stable/", `invalid channel constraint "2.0/stable/": missing branch`},
+		{">=latest/stable", `invalid channel constraint ">=latest/stable": cannot compare track with non version "latest"`},
+		{">v2", `invalid channel constraint ">v2": cannot compare track with non version "v2"`},
+	}
+	for _, t := range tests {
+		_, err := channel.ParseConstraint(t.constraint)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *constraintSuite) TestPick(c *gc.C) {
+	candidates := []channel.Channel{
+		mustParse(c, "2.9/stable"),
+		mustParse(c, "3.1/edge"),
+		mustParse(c, "3.1/candidate"),
+		mustParse(c, "3.1/stable"),
+		mustParse(c, "3.10/candidate"),
+		mustParse(c, "3.2/stable"),
+	}
+	picked, ok := channel.MustParseConstraint("3.*/>=candidate").Pick(candidates)
+	c.Assert(ok, jc.IsTrue)
+	c.Check(picked.String(), gc.Equals, "3.10/candidate")
+
+	picked, ok = channel.MustParseConstraint("3.1/*").Pick(candidates)
+	c.Assert(ok, jc.IsTrue)
+	c.Check(picked.String(), gc.Equals, "3.1/stable")
+
+	_, ok = channel.MustParseConstraint("4.*").Pick(candidates)
+	c.Check(ok, jc.IsFalse)
+}
+
+func (s *constraintSuite) TestPickPrefersVersionTracks(c *gc.C) {
+	candidates := []channel.Channel{
+		mustParse(c, "latest/stable"),
+		mustParse(c, "2.9/stable"),
+		mustParse(c, "insiders/stable"),
+		mustParse(c, "3.0/stable"),
+	}
+	picked, ok := channel.MustParseConstraint("*/stable").Pick(candidates)
+	c.Assert(ok, jc.IsTrue)
+	c.Check(picked.Full(), gc.Equals, "3.0/stable")
+
+	picked, ok = channel.MustParseConstraint("*/stable").Pick(candidates[:1])
+	c.Assert(ok, jc.IsTrue)
+	c.Check(picked.Full(), gc.Equals, "latest/stable")
+
+	_, ok = channel.MustParseConstraint(">=2.9/stable").Pick(candidates[:1])
+	c.Check(ok, jc.IsFalse)
+}
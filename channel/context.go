@@ -0,0 +1,106 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package channel
+
+// DefaultTrack is the track implied when a channel has none, unless a
+// publisher sets a different default track.
+const DefaultTrack = "latest"
+
+// DefaultContext is the normalization context used by the package level
+// functions and the Channel methods.
+var DefaultContext = Context{DefaultTrack: DefaultTrack}
+
+// Context holds the settings used to normalize channels. A channel without
+// a track given to a context is on the context's default track. Channels
+// returned by a context with a default track other than "latest" keep their
+// track, so they mean the same outside of the context; only Context.Format
+// elides the default track.
+type Context struct {
+	// DefaultTrack is the track implied when a channel has none, as set
+	// by the publisher. When empty, "latest" is used.
+	DefaultTrack string
+}
+
+func (ctx Context) defaultTrack() string {
+	if ctx.DefaultTrack == "" {
+		return DefaultTrack
+	}
+	return ctx.DefaultTrack
+}
+
+// Parse parses a string representing a store channel and normalizes it with
+// Clean, e.g. "stable" is parsed as "3/stable" when the default track is "3".
+func (ctx Context) Parse(s string) (Channel, error) {
+	channel, err := ParseVerbatim(s)
+	if err != nil {
+		return Empty, err
+	}
+	return ctx.Clean(channel), nil
+}
+
+// Clean returns a Channel with a normalized track, risk and name. A channel
+// without a track is put on the context's default track. As with
+// Channel.Clean, the track is elided when it is "latest" and the context's
+// default track is "latest" too; any other track is kept, so that the
+// channel is not mistaken for one on the "latest" track once it leaves the
+// context.
+func (ctx Context) Clean(c Channel) Channel {
+	mode := FormatFull
+	if ctx.defaultTrack() == DefaultTrack {
+		mode = FormatStore
+	}
+	track, risk, branch := c.normalize(ctx.defaultTrack(), mode)
+	return Channel{
+		Name:   joinComponents(track, risk, branch),
+		Track:  track,
+		Risk:   risk,
+		Branch: branch,
+	}
+}
+
+// Format returns the name of the channel normalized according to mode. The
+// context's default track is elided, except in FormatFull.
+func (ctx Context) Format(c Channel, mode FormatMode) string {
+	return joinComponents(c.normalize(ctx.defaultTrack(), mode))
+}
+
+// Full returns the full name of the channel, inclusive the context's default
+// track.
+func (ctx Context) Full(c Channel) string {
+	return ctx.Format(c, FormatFull)
+}
+
+// Resolve works like the package level Resolve, but a resolved channel
+// without a track is qualified with the context's default track, unless it
+// is "latest".
+func (ctx Context) Resolve(channel, newChannel string) (string, error) {
+	resolved, err := Resolve(channel, newChannel)
+	if err != nil {
+		return "", err
+	}
+	return ctx.qualify(resolved), nil
+}
+
+// ResolvePinned works like the package level ResolvePinned, but a resolved
+// channel without a track is qualified with the context's default track,
+// unless it is "latest".
+func (ctx Context) ResolvePinned(track, newChannel string) (string, error) {
+	resolved, err := ResolvePinned(track, newChannel)
+	if err != nil {
+		return "", err
+	}
+	return ctx.qualify(resolved), nil
+}
+
+// qualify prefixes a risk/branch-only channel string with the default track.
+func (ctx Context) qualify(s string) string {
+	if s == "" || ctx.defaultTrack() == DefaultTrack {
+		return s
+	}
+	ch, err := ParseVerbatim(s)
+	if err != nil || ch.Track != "" {
+		return s
+	}
+	return ctx.defaultTrack() + "/" + s
+}
@@ -0,0 +1,145 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package channel_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/systems/channel"
+)
+
+type contextSuite struct{}
+
+var _ = gc.Suite(&contextSuite{})
+
+func (s *contextSuite) TestDefaultContextMatchesPackage(c *gc.C) {
+	for _, str := range []string{"stable", "latest/stable", "1.0", "1.0/beta/foo", "candidate/foo"} {
+		ch, err := channel.DefaultContext.Parse(str)
+		c.Assert(err, gc.IsNil)
+		c.Check(ch, jc.DeepEquals, mustParse(c, str))
+		c.Check(channel.DefaultContext.Full(ch), gc.Equals, ch.Full())
+	}
+	c.Check(channel.Context{}.Full(mustParse(c, "edge")), gc.Equals, "latest/edge")
+}
+
+func (s *contextSuite) TestParseWithDefaultTrack(c *gc.C) {
+	ctx := channel.Context{DefaultTrack: "3"}
+	tests := []struct {
+		channel string
+		store   string
+		full    string
+	}{
+		{"stable", "stable", "3/stable"},
+		{"3/stable", "stable", "3/stable"},
+		{"3", "stable", "3/stable"},
+		{"edge/foo", "edge/foo", "3/edge/foo"},
+		{"latest/stable", "latest/stable", "latest/stable"},
+		{"2/candidate", "2/candidate", "2/candidate"},
+	}
+	for _, t := range tests {
+		comment := gc.Commentf("%q", t.channel)
+		ch, err := ctx.Parse(t.channel)
+		c.Assert(err, gc.IsNil, comment)
+		c.Check(ch.String(), gc.Equals, t.full, comment)
+		c.Check(ctx.Full(ch), gc.Equals, t.full, comment)
+		c.Check(ctx.Format(ch, channel.FormatStore), gc.Equals, t.store, comment)
+		// The channel keeps its track outside of the context.
+		c.Check(ch.Full(), gc.Equals, t.full, comment)
+	}
+
+	_, err := ctx.Parse("3/bogus")
+	c.Check(err, gc.ErrorMatches, "invalid risk in channel name: 3/bogus")
+}
+
+func (s *contextSuite) TestParseKeepsTrack(c *gc.C) {
+	ctx := channel.Context{DefaultTrack: "3"}
+	ch, err := ctx.Parse("stable")
+	c.Assert(err, gc.IsNil)
+	c.Check(ch, jc.DeepEquals, channel.Channel{Name: "3/stable", Track: "3", Risk: channel.Stable})
+
+	// Outside of the context it is not mistaken for latest/stable.
+	c.Check(ch.Compare(mustParse(c, "latest/stable")), gc.Not(gc.Equals), 0)
+	c.Check(ch.Compare(mustParse(c, "3/stable")), gc.Equals, 0)
+	v, err := ch.Value()
+	c.Assert(err, gc.IsNil)
+	c.Check(v, gc.Equals, "3/stable")
+}
+
+func (s *contextSuite) TestClean(c *gc.C) {
+	ctx := channel.Context{DefaultTrack: "3"}
+	c.Check(ctx.Clean(channel.Channel{Track: "3", Risk: channel.Beta}), jc.DeepEquals, channel.Channel{
+		Name:  "3/beta",
+		Track: "3",
+		Risk:  channel.Beta,
+	})
+	c.Check(ctx.Clean(channel.Channel{Risk: channel.Beta}), jc.DeepEquals, channel.Channel{
+		Name:  "3/beta",
+		Track: "3",
+		Risk:  channel.Beta,
+	})
+	c.Check(ctx.Clean(channel.Channel{Track: "latest", Risk: channel.Beta}), jc.DeepEquals, channel.Channel{
+		Name:  "latest/beta",
+		Track: "latest",
+		Risk:  channel.Beta,
+	})
+	c.Check(channel.DefaultContext.Clean(channel.Channel{Track: "latest", Risk: channel.Beta}), jc.DeepEquals, channel.Channel{
+		Name: "beta",
+		Risk: channel.Beta,
+	})
+}
+
+func (s *contextSuite) TestResolve(c *gc.C) {
+	ctx := channel.Context{DefaultTrack: "3"}
+	tests := []struct {
+		channel string
+		new     string
+		result  string
+	}{
+		{"", "", ""},
+		{"", "edge", "3/edge"},
+		{"stable", "edge", "3/edge"},
+		{"stable", "", "3/stable"},
+		{"2/stable", "beta", "2/beta"},
+		{"stable", "latest/beta", "latest/beta"},
+	}
+	for _, t := range tests {
+		r, err := ctx.Resolve(t.channel, t.new)
+		c.Assert(err, gc.IsNil)
+		c.Check(r, gc.Equals, t.result, gc.Commentf("%#v", t))
+
+		// The default context behaves like the package function.
+		expected, err := channel.Resolve(t.channel, t.new)
+		c.Assert(err, gc.IsNil)
+		r, err = channel.DefaultContext.Resolve(t.channel, t.new)
+		c.Assert(err, gc.IsNil)
+		c.Check(r, gc.Equals, expected)
+	}
+}
+
+func (s *contextSuite) TestResolvePinned(c *gc.C) {
+	ctx := channel.Context{DefaultTrack: "3"}
+	tests := []struct {
+		track  string
+		new    string
+		result string
+		expErr string
+	}{
+		{"", "edge", "3/edge", ""},
+		{"3", "edge", "3/edge", ""},
+		{"3", "", "3", ""},
+		{"2", "stable/branch", "2/stable/branch", ""},
+		{"2", "3/stable", "", "cannot switch pinned track"},
+	}
+	for _, t := range tests {
+		r, err := ctx.ResolvePinned(t.track, t.new)
+		tcomm := gc.Commentf("%#v", t)
+		if t.expErr != "" {
+			c.Check(err, gc.ErrorMatches, t.expErr, tcomm)
+			continue
+		}
+		c.Assert(err, gc.IsNil, tcomm)
+		c.Check(r, gc.Equals, t.result, tcomm)
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package channel
+
+import (
+	"flag"
+
+	"github.com/juju/gnuflag"
+)
+
+var (
+	_ flag.Getter    = (*ChannelFlag)(nil)
+	_ gnuflag.Getter = (*ChannelFlag)(nil)
+)
+
+// ChannelFlag is a command line flag holding a channel, for use with both the
+// standard library flag package and gnuflag, e.g. for --channel. It accepts
+// the syntax of ParseVerbatim and keeps the channel verbatim, so that a
+// risk-only channel can later be resolved against the current track with
+// ResolveChannel.
+type ChannelFlag struct {
+	// Channel holds the parsed channel, or the default channel when the
+	// flag was not set.
+	Channel Channel
+	// IsSet is true when the flag was set explicitly on the command line.
+	IsSet bool
+}
+
+// NewChannelFlag returns a flag holding the given default channel.
+func NewChannelFlag(defaultChannel Channel) *ChannelFlag {
+	return &ChannelFlag{Channel: defaultChannel}
+}
+
+// Set implements flag.Value and gnuflag.Value.
+func (f *ChannelFlag) Set(s string) error {
+	ch, err := ParseVerbatim(s)
+	if err != nil {
+		return err
+	}
+	f.Channel = ch
+	f.IsSet = true
+	return nil
+}
+
+// String implements flag.Value and gnuflag.Value.
+func (f *ChannelFlag) String() string {
+	if f == nil || f.Channel == Empty {
+		return ""
+	}
+	return f.Channel.Format(FormatVerbatim)
+}
+
+// Get implements flag.Getter and gnuflag.Getter.
+func (f *ChannelFlag) Get() interface{} {
+	if f == nil {
+		return Empty
+	}
+	return f.Channel
+}
@@ -0,0 +1,64 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package channel_test
+
+import (
+	"flag"
+	"io/ioutil"
+
+	"github.com/juju/gnuflag"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/systems/channel"
+)
+
+type flagSuite struct{}
+
+var _ = gc.Suite(&flagSuite{})
+
+func (s *flagSuite) TestStdlibFlag(c *gc.C) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	f := channel.NewChannelFlag(channel.MustParse("stable"))
+	fs.Var(f, "channel", "the channel")
+
+	c.Assert(fs.Parse(nil), jc.ErrorIsNil)
+	c.Check(f.IsSet, jc.IsFalse)
+	c.Check(f.String(), gc.Equals, "stable")
+
+	c.Assert(fs.Parse([]string{"-channel", "edge"}), jc.ErrorIsNil)
+	c.Check(f.IsSet, jc.IsTrue)
+	c.Check(f.Channel, jc.DeepEquals, channel.Channel{Risk: channel.Edge})
+	c.Check(fs.Lookup("channel").Value.(flag.Getter).Get(), jc.DeepEquals, f.Channel)
+}
+
+func (s *flagSuite) TestGnuflag(c *gc.C) {
+	fs := gnuflag.NewFlagSet("test", gnuflag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	var f channel.ChannelFlag
+	fs.Var(&f, "channel", "the channel")
+
+	c.Assert(fs.Parse(true, []string{"--channel", "2.0/candidate/fix"}), jc.ErrorIsNil)
+	c.Check(f.IsSet, jc.IsTrue)
+	c.Check(f.String(), gc.Equals, "2.0/candidate/fix")
+}
+
+func (s *flagSuite) TestInvalid(c *gc.C) {
+	fs := gnuflag.NewFlagSet("test", gnuflag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	var f channel.ChannelFlag
+	fs.Var(&f, "channel", "the channel")
+
+	err := fs.Parse(true, []string{"--channel", "a/b/c/d"})
+	c.Check(err, gc.ErrorMatches, `invalid value "a/b/c/d" for flag --channel: channel name has too many components: a/b/c/d`)
+	c.Check(f.IsSet, jc.IsFalse)
+	c.Check(f.String(), gc.Equals, "")
+}
+
+func (s *flagSuite) TestZeroValue(c *gc.C) {
+	var f *channel.ChannelFlag
+	c.Check(f.String(), gc.Equals, "")
+	c.Check(f.Get(), jc.DeepEquals, channel.Empty)
+}
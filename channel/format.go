@@ -0,0 +1,102 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package channel
+
+import (
+	"strings"
+)
+
+// FormatMode selects how a channel is normalized by Channel.Format.
+//
+// The guarantees of each mode are:
+//
+//  mode      track "latest"  missing risk  "stable" risk              example ("latest/stable", "1.0/stable")
+//  Verbatim  kept as set     kept empty    kept                       "latest/stable", "1.0/stable"
+//  Short     elided          stable        elided after a track       "stable", "1.0"
+//  Full      always present  stable        kept                       "latest/stable", "1.0/stable"
+//  Store     elided          stable        kept                       "stable", "1.0/stable"
+//
+// For a valid channel, parsing the output of any mode with Parse returns
+// the same channel as Clean. Store is the form used by Clean and String of
+// cleaned channels, Full is the form returned by Full.
+type FormatMode int
+
+const (
+	// FormatVerbatim joins the channel components as they are set.
+	FormatVerbatim FormatMode = iota
+	// FormatShort returns the shortest name that parses back to the
+	// same channel.
+	FormatShort
+	// FormatFull always includes the track and risk.
+	FormatFull
+	// FormatStore elides the default track and always includes the risk,
+	// as the store reports channel names.
+	FormatStore
+)
+
+// Format returns the name of the channel normalized according to mode, with
+// "latest" as the default track.
+func (c Channel) Format(mode FormatMode) string {
+	return DefaultContext.Format(c, mode)
+}
+
+// normalize is the single normalization core behind Format, Clean and Full.
+// An empty track stands for the default track.
+func (c Channel) normalize(defaultTrack string, mode FormatMode) (string, Risk, string) {
+	track, risk, branch := c.Track, c.Risk, c.Branch
+	if mode == FormatVerbatim {
+		return track, risk, branch
+	}
+
+	switch {
+	case mode == FormatFull && track == "":
+		track = defaultTrack
+	case mode != FormatFull && track == defaultTrack:
+		track = ""
+	}
+	if risk == "" {
+		risk = Stable
+	}
+	if mode == FormatShort && track != "" && branch == "" && risk == Stable {
+		risk = ""
+	}
+	return track, risk, branch
+}
+
+func joinComponents(track string, risk Risk, branch string) string {
+	components := make([]string, 0, 3)
+	for _, c := range []string{track, string(risk), branch} {
+		if c != "" {
+			components = append(components, c)
+		}
+	}
+	return strings.Join(components, "/")
+}
+
+// parseLenient splits a channel string into its components the way the
+// store does: empty components are ignored and the risk is not validated.
+func parseLenient(s string) (Channel, bool) {
+	components := strings.FieldsFunc(s, isSlash)
+	var ch Channel
+	switch len(components) {
+	case 0:
+	case 1:
+		if channelRisks.Contains(components[0]) {
+			ch.Risk = Risk(components[0])
+		} else {
+			ch.Track = components[0]
+		}
+	case 2:
+		if channelRisks.Contains(components[0]) {
+			ch.Risk, ch.Branch = Risk(components[0]), components[1]
+		} else {
+			ch.Track, ch.Risk = components[0], Risk(components[1])
+		}
+	case 3:
+		ch.Track, ch.Risk, ch.Branch = components[0], Risk(components[1]), components[2]
+	default:
+		return Empty, false
+	}
+	return ch, true
+}
@@ -0,0 +1,61 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package channel_test
+
+import (
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/systems/channel"
+)
+
+type formatSuite struct{}
+
+var _ = gc.Suite(&formatSuite{})
+
+func (s *formatSuite) TestFormat(c *gc.C) {
+	tests := []struct {
+		channel  string
+		verbatim string
+		short    string
+		full     string
+		store    string
+	}{
+		{"stable", "stable", "stable", "latest/stable", "stable"},
+		{"latest", "latest", "stable", "latest/stable", "stable"},
+		{"latest/stable", "latest/stable", "stable", "latest/stable", "stable"},
+		{"edge", "edge", "edge", "latest/edge", "edge"},
+		{"1.0", "1.0", "1.0", "1.0/stable", "1.0/stable"},
+		{"1.0/stable", "1.0/stable", "1.0", "1.0/stable", "1.0/stable"},
+		{"1.0/edge", "1.0/edge", "1.0/edge", "1.0/edge", "1.0/edge"},
+		{"1.0/stable/foo", "1.0/stable/foo", "1.0/stable/foo", "1.0/stable/foo", "1.0/stable/foo"},
+		{"candidate/foo", "candidate/foo", "candidate/foo", "latest/candidate/foo", "candidate/foo"},
+		{"latest/beta/foo", "latest/beta/foo", "beta/foo", "latest/beta/foo", "beta/foo"},
+	}
+	for _, t := range tests {
+		comment := gc.Commentf("%q", t.channel)
+		ch, err := channel.ParseVerbatim(t.channel)
+		c.Assert(err, gc.IsNil, comment)
+		c.Check(ch.Format(channel.FormatVerbatim), gc.Equals, t.verbatim, comment)
+		c.Check(ch.Format(channel.FormatShort), gc.Equals, t.short, comment)
+		c.Check(ch.Format(channel.FormatFull), gc.Equals, t.full, comment)
+		c.Check(ch.Format(channel.FormatStore), gc.Equals, t.store, comment)
+
+		// Every mode parses back to the cleaned channel.
+		for _, mode := range []channel.FormatMode{
+			channel.FormatVerbatim, channel.FormatShort, channel.FormatFull, channel.FormatStore,
+		} {
+			parsed, err := channel.Parse(ch.Format(mode))
+			c.Assert(err, gc.IsNil, comment)
+			c.Check(parsed, gc.Equals, ch.Clean(), comment)
+		}
+
+		// The existing functions are wrappers around the modes.
+		c.Check(ch.Clean().String(), gc.Equals, t.store, comment)
+		full, err := channel.Full(t.channel)
+		c.Assert(err, gc.IsNil, comment)
+		c.Check(full, gc.Equals, t.full, comment)
+		cleaned := ch.Clean()
+		c.Check(cleaned.Full(), gc.Equals, t.full, comment)
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package channel
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// JSONSchemaDraft is the JSON Schema dialect of the generated documents.
+const JSONSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// JSONSchemaDefinitions returns the JSON Schema definitions describing
+// channels, to be embedded under "$defs" in a JSON Schema document:
+//  - "risk": the well-known risks;
+//  - "channelString": the string form accepted by ParseVerbatim;
+//  - "channelObject": the object form of the JSON encoding of Channel;
+//  - "channel": either form.
+func JSONSchemaDefinitions() map[string]interface{} {
+	risks := AllRisks()
+	names := make([]string, len(risks))
+	for i, r := range risks {
+		names[i] = string(r)
+	}
+	risk := "(?:" + strings.Join(names, "|") + ")"
+	return map[string]interface{}{
+		"risk": map[string]interface{}{
+			"type": "string",
+			"enum": names,
+		},
+		"channelString": map[string]interface{}{
+			"type":    "string",
+			"pattern": "^(?:[^/]+|(?:[^/]+/)?" + risk + "(?:/[^/]+)?)$",
+			"examples": []string{
+				"stable", "2.0/edge", "latest/candidate/hotfix",
+			},
+		},
+		"channelObject": map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"name":  map[string]interface{}{"type": "string"},
+				"track": map[string]interface{}{"type": "string"},
+				// A verbatim channel holding only a track has no risk.
+				"risk": map[string]interface{}{
+					"anyOf": []interface{}{
+						map[string]interface{}{"$ref": "#/$defs/risk"},
+						map[string]interface{}{"const": ""},
+					},
+				},
+				"branch": map[string]interface{}{"type": "string"},
+			},
+			"additionalProperties": false,
+		},
+		"channel": map[string]interface{}{
+			"oneOf": []interface{}{
+				map[string]interface{}{"$ref": "#/$defs/channelString"},
+				map[string]interface{}{"$ref": "#/$defs/channelObject"},
+			},
+		},
+	}
+}
+
+// JSONSchema returns a JSON Schema document validating a channel in either
+// its string or its object form.
+func JSONSchema() ([]byte, error) {
+	return json.MarshalIndent(map[string]interface{}{
+		"$schema": JSONSchemaDraft,
+		"title":   "Channel",
+		"$ref":    "#/$defs/channel",
+		"$defs":   JSONSchemaDefinitions(),
+	}, "", "  ")
+}
@@ -0,0 +1,46 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package channel_test
+
+import (
+	"encoding/json"
+	"regexp"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/systems/channel"
+)
+
+type jsonSchemaSuite struct{}
+
+var _ = gc.Suite(&jsonSchemaSuite{})
+
+func (s *jsonSchemaSuite) TestJSONSchema(c *gc.C) {
+	doc, err := channel.JSONSchema()
+	c.Assert(err, jc.ErrorIsNil)
+	var schema struct {
+		Ref  string `json:"$ref"`
+		Defs struct {
+			Risk struct {
+				Enum []string `json:"enum"`
+			} `json:"risk"`
+		} `json:"$defs"`
+	}
+	c.Assert(json.Unmarshal(doc, &schema), jc.ErrorIsNil)
+	c.Check(schema.Ref, gc.Equals, "#/$defs/channel")
+	c.Check(schema.Defs.Risk.Enum, jc.DeepEquals, []string{"stable", "candidate", "beta", "edge"})
+}
+
+func (s *jsonSchemaSuite) TestChannelStringPattern(c *gc.C) {
+	def := channel.JSONSchemaDefinitions()["channelString"].(map[string]interface{})
+	pattern := regexp.MustCompile(def["pattern"].(string))
+	for _, str := range []string{
+		"stable", "2.0", "2.0/edge", "edge/fix", "latest/candidate/hotfix",
+		"", "2.0/foo", "a/stable/", "/stable", "a/b/c/d",
+	} {
+		_, err := channel.ParseVerbatim(str)
+		c.Check(pattern.MatchString(str), gc.Equals, err == nil, gc.Commentf("%q: %v", str, err))
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package channel
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Store limits on the length of track and branch names.
+const (
+	MaxTrackLength  = 28
+	MaxBranchLength = 128
+)
+
+// validName matches the names the store accepts for tracks and branches:
+// lowercase letters and digits, optionally separated by a single '.', '-'
+// or '_'.
+var validName = regexp.MustCompile(`^[a-z0-9](?:[._-]?[a-z0-9])*$`)
+
+// NameError is returned when a track or branch name is rejected by the
+// store naming rules or by a track guardrail.
+type NameError struct {
+	// Component is either "track" or "branch".
+	Component string
+	Name      string
+	Reason    string
+}
+
+// Error implements error.
+func (e *NameError) Error() string {
+	return fmt.Sprintf("invalid %s %q: %s", e.Component, e.Name, e.Reason)
+}
+
+func validateName(component, name string, maxLength int) error {
+	switch {
+	case name == "":
+		return &NameError{Component: component, Name: name, Reason: "cannot be empty"}
+	case len(name) > maxLength:
+		return &NameError{Component: component, Name: name, Reason: fmt.Sprintf("longer than %d characters", maxLength)}
+	case !validName.MatchString(name):
+		return &NameError{Component: component, Name: name, Reason: "must be lowercase letters and digits, separated by '.', '-' or '_'"}
+	}
+	return nil
+}
+
+// ValidateTrack checks that the track follows the store naming rules, and,
+// when guardrails are given, that it matches at least one of them.
+// Guardrails are regular expressions matched against the whole track name,
+// e.g. "2.*" or "v[0-9]+". The default track "latest" is always permitted.
+func ValidateTrack(track string, guardrails []string) error {
+	if err := validateName("track", track, MaxTrackLength); err != nil {
+		return err
+	}
+	if channelRisks.Contains(track) {
+		return &NameError{Component: "track", Name: track, Reason: "risk names are reserved"}
+	}
+	if track == DefaultTrack || len(guardrails) == 0 {
+		return nil
+	}
+	for _, g := range guardrails {
+		re, err := regexp.Compile(`^(?:` + g + `)$`)
+		if err != nil {
+			return fmt.Errorf("invalid track guardrail %q: %v", g, err)
+		}
+		if re.MatchString(track) {
+			return nil
+		}
+	}
+	return &NameError{Component: "track", Name: track, Reason: fmt.Sprintf("does not match guardrails %q", guardrails)}
+}
+
+// ValidateBranch checks that the branch follows the store naming rules.
+func ValidateBranch(branch string) error {
+	return validateName("branch", branch, MaxBranchLength)
+}
+
+// ValidateNames checks the track and branch names of the channel with
+// ValidateTrack and ValidateBranch. Empty components are not checked.
+func (c Channel) ValidateNames(guardrails []string) error {
+	if c.Track != "" {
+		if err := ValidateTrack(c.Track, guardrails); err != nil {
+			return err
+		}
+	}
+	if c.Branch != "" {
+		if err := ValidateBranch(c.Branch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
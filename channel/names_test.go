@@ -0,0 +1,73 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package channel_test
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/systems/channel"
+)
+
+type namesSuite struct{}
+
+var _ = gc.Suite(&namesSuite{})
+
+func (s *namesSuite) TestValidateTrack(c *gc.C) {
+	tests := []struct {
+		track      string
+		guardrails []string
+		err        string
+	}{
+		{"latest", nil, ""},
+		{"2.0", nil, ""},
+		{"v1_2-3", nil, ""},
+		{"", nil, `invalid track "": cannot be empty`},
+		{"Latest", nil, `invalid track "Latest": must be lowercase letters and digits, separated by '.', '-' or '_'`},
+		{"two words", nil, `invalid track "two words": must be .*`},
+		{"ünicode", nil, `invalid track "ünicode": must be .*`},
+		{"1..0", nil, `invalid track "1..0": must be .*`},
+		{"-1", nil, `invalid track "-1": must be .*`},
+		{"1.0-", nil, `invalid track "1.0-": must be .*`},
+		{strings.Repeat("a", 29), nil, `invalid track "a+": longer than 28 characters`},
+		{"edge", nil, `invalid track "edge": risk names are reserved`},
+		{"2.1", []string{"2.*"}, ""},
+		{"v12", []string{"2.*", "v[0-9]+"}, ""},
+		{"latest", []string{"2.*"}, ""},
+		{"3.0", []string{"2.*", "v[0-9]+"}, `invalid track "3.0": does not match guardrails \["2.\*" "v\[0-9\]\+"\]`},
+		{"v1x", []string{"v[0-9]+"}, `invalid track "v1x": does not match guardrails .*`},
+		{"2.0", []string{"2.(*"}, `invalid track guardrail "2.\(\*": .*`},
+	}
+	for _, t := range tests {
+		err := channel.ValidateTrack(t.track, t.guardrails)
+		comment := gc.Commentf("%q %q", t.track, t.guardrails)
+		if t.err == "" {
+			c.Check(err, jc.ErrorIsNil, comment)
+		} else {
+			c.Check(err, gc.ErrorMatches, t.err, comment)
+		}
+	}
+}
+
+func (s *namesSuite) TestValidateBranch(c *gc.C) {
+	c.Check(channel.ValidateBranch("fix-123"), jc.ErrorIsNil)
+	c.Check(channel.ValidateBranch(strings.Repeat("a", 128)), jc.ErrorIsNil)
+	c.Check(channel.ValidateBranch(strings.Repeat("a", 129)), gc.ErrorMatches, `invalid branch "a+": longer than 128 characters`)
+	c.Check(channel.ValidateBranch("Fix 1"), gc.ErrorMatches, `invalid branch "Fix 1": must be .*`)
+}
+
+func (s *namesSuite) TestValidateNames(c *gc.C) {
+	c.Check(mustParse(c, "2.1/edge/fix").ValidateNames([]string{"2.*"}), jc.ErrorIsNil)
+	c.Check(mustParse(c, "edge/fix").ValidateNames([]string{"2.*"}), jc.ErrorIsNil)
+
+	err := mustParse(c, "3.0/edge").ValidateNames([]string{"2.*"})
+	c.Assert(err, gc.FitsTypeOf, &channel.NameError{})
+	c.Check(err.(*channel.NameError).Component, gc.Equals, "track")
+
+	err = mustParse(c, "2.1/edge/Fix").ValidateNames(nil)
+	c.Assert(err, gc.FitsTypeOf, &channel.NameError{})
+	c.Check(err.(*channel.NameError).Component, gc.Equals, "branch")
+}
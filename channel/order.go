@@ -0,0 +1,97 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package channel
+
+import (
+	"sort"
+	"strings"
+)
+
+// Compare returns an integer comparing two channels in the order the store
+// displays them. Channels are ordered by track, with "latest" first and then
+// the other tracks by descending version, see CompareTracks, and then
+// alphabetically; then by risk, from the most to the least stable; then
+// channels that are not on a branch come before branches, which are ordered
+// alphabetically. The result is negative if c comes before other, zero if
+// they are the same channel once cleaned and positive if c comes after other.
+func (c Channel) Compare(other Channel) int {
+	a, b := c.Clean(), other.Clean()
+	if cmp := compareDisplayTracks(a.Track, b.Track); cmp != 0 {
+		return cmp
+	}
+	if cmp := a.Risk.Compare(b.Risk); cmp != 0 {
+		return cmp
+	}
+	if cmp := strings.Compare(string(a.Risk), string(b.Risk)); cmp != 0 {
+		// Unknown risks share the same level.
+		return cmp
+	}
+	switch {
+	case a.Branch == b.Branch:
+		return 0
+	case a.Branch == "":
+		return -1
+	case b.Branch == "":
+		return 1
+	}
+	return strings.Compare(a.Branch, b.Branch)
+}
+
+// compareDisplayTracks orders tracks with the default track first and then
+// by descending version. Tracks of the same version, such as 1.0 and 1.00,
+// are ordered alphabetically so that the order is total.
+func compareDisplayTracks(a, b string) int {
+	a, b = fullTrack(a), fullTrack(b)
+	switch {
+	case a == b:
+		return 0
+	case a == DefaultTrack:
+		return -1
+	case b == DefaultTrack:
+		return 1
+	}
+	if cmp := CompareTracks(b, a); cmp != 0 {
+		return cmp
+	}
+	return strings.Compare(a, b)
+}
+
+// Channels is a list of channels sortable in the order defined by
+// Channel.Compare.
+type Channels []Channel
+
+// Len implements sort.Interface.
+func (cs Channels) Len() int { return len(cs) }
+
+// Less implements sort.Interface.
+func (cs Channels) Less(i, j int) bool { return cs[i].Compare(cs[j]) < 0 }
+
+// Swap implements sort.Interface.
+func (cs Channels) Swap(i, j int) { cs[i], cs[j] = cs[j], cs[i] }
+
+// TrackGroup holds the channels of a single track.
+type TrackGroup struct {
+	// Track is the name of the track, inclusive the default track "latest".
+	Track    string
+	Channels Channels
+}
+
+// ByTrack returns the channels grouped by track, in the order the store
+// displays them. The channels themselves are not modified.
+func (cs Channels) ByTrack() []TrackGroup {
+	sorted := make(Channels, len(cs))
+	copy(sorted, cs)
+	sort.Stable(sorted)
+
+	var groups []TrackGroup
+	for _, ch := range sorted {
+		track := fullTrack(ch.Clean().Track)
+		if n := len(groups); n > 0 && groups[n-1].Track == track {
+			groups[n-1].Channels = append(groups[n-1].Channels, ch)
+			continue
+		}
+		groups = append(groups, TrackGroup{Track: track, Channels: Channels{ch}})
+	}
+	return groups
+}
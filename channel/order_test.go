@@ -0,0 +1,120 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package channel_test
+
+import (
+	"sort"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/systems/channel"
+)
+
+type orderSuite struct{}
+
+var _ = gc.Suite(&orderSuite{})
+
+func parseChannels(c *gc.C, names ...string) channel.Channels {
+	var cs channel.Channels
+	for _, name := range names {
+		ch, err := channel.ParseVerbatim(name)
+		c.Assert(err, jc.ErrorIsNil)
+		cs = append(cs, ch)
+	}
+	return cs
+}
+
+func fullNames(cs channel.Channels) []string {
+	var names []string
+	for _, ch := range cs {
+		names = append(names, ch.Full())
+	}
+	return names
+}
+
+func (s *orderSuite) TestCompare(c *gc.C) {
+	tests := []struct {
+		a, b string
+		cmp  int
+	}{
+		{"stable", "latest/stable", 0},
+		{"stable", "edge", -1},
+		{"edge", "candidate", 1},
+		{"latest/edge", "2.0/stable", -1},
+		{"2.10/stable", "2.9/stable", -1},
+		{"2.9/stable", "2.10/stable", 1},
+		{"stable", "stable/hotfix", -1},
+		{"stable/a", "stable/b", -1},
+		{"stable/b", "candidate", -1},
+		{"3.0/edge", "insiders/stable", 1},
+		{"1.0/stable", "1.00/stable", -1},
+		{"1.00/stable", "1.0/stable", 1},
+		{"1.00/edge", "1.0/stable", 1},
+	}
+	for i, test := range tests {
+		c.Logf("test %d: %s <=> %s", i, test.a, test.b)
+		cs := parseChannels(c, test.a, test.b)
+		cmp := cs[0].Compare(cs[1])
+		switch {
+		case test.cmp < 0:
+			c.Check(cmp < 0, jc.IsTrue)
+		case test.cmp > 0:
+			c.Check(cmp > 0, jc.IsTrue)
+		default:
+			c.Check(cmp, gc.Equals, 0)
+		}
+	}
+}
+
+func (s *orderSuite) TestSort(c *gc.C) {
+	cs := parseChannels(c,
+		"edge", "2.9/stable", "stable/hotfix", "beta", "2.10/edge",
+		"stable", "2.10/stable", "candidate/b", "candidate/a", "latest/candidate",
+	)
+	sort.Sort(cs)
+	c.Assert(fullNames(cs), jc.DeepEquals, []string{
+		"latest/stable",
+		"latest/stable/hotfix",
+		"latest/candidate",
+		"latest/candidate/a",
+		"latest/candidate/b",
+		"latest/beta",
+		"latest/edge",
+		"2.10/stable",
+		"2.10/edge",
+		"2.9/stable",
+	})
+}
+
+func (s *orderSuite) TestByTrack(c *gc.C) {
+	cs := parseChannels(c, "2.0/edge", "edge", "3.0/stable", "stable", "2.0/stable")
+	groups := cs.ByTrack()
+	c.Assert(groups, gc.HasLen, 3)
+	var tracks []string
+	for _, g := range groups {
+		tracks = append(tracks, g.Track)
+	}
+	c.Check(tracks, jc.DeepEquals, []string{"latest", "3.0", "2.0"})
+	c.Check(fullNames(groups[0].Channels), jc.DeepEquals, []string{"latest/stable", "latest/edge"})
+	c.Check(fullNames(groups[1].Channels), jc.DeepEquals, []string{"3.0/stable"})
+	c.Check(fullNames(groups[2].Channels), jc.DeepEquals, []string{"2.0/stable", "2.0/edge"})
+
+	// The original list is left untouched.
+	c.Check(cs[0].Format(channel.FormatVerbatim), gc.Equals, "2.0/edge")
+}
+
+func (s *orderSuite) TestByTrackSameVersion(c *gc.C) {
+	cs := parseChannels(c, "1.00/stable", "1.0/edge", "1.00/edge", "1.0/stable")
+	groups := cs.ByTrack()
+	c.Assert(groups, gc.HasLen, 2)
+	c.Check(groups[0].Track, gc.Equals, "1.0")
+	c.Check(fullNames(groups[0].Channels), jc.DeepEquals, []string{"1.0/stable", "1.0/edge"})
+	c.Check(groups[1].Track, gc.Equals, "1.00")
+	c.Check(fullNames(groups[1].Channels), jc.DeepEquals, []string{"1.00/stable", "1.00/edge"})
+}
+
+func (s *orderSuite) TestByTrackEmpty(c *gc.C) {
+	c.Assert(channel.Channels(nil).ByTrack(), gc.HasLen, 0)
+}
@@ -0,0 +1,46 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package channel
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+)
+
+// Progressive describes a progressive release: a new revision rolled out to
+// a percentage of the devices following a channel, while the others stay on
+// the revision of the release.
+type Progressive struct {
+	// Revision is the revision being rolled out.
+	Revision int
+	// Percentage of the cohorts that get the new revision, from 0 to 100.
+	Percentage float64
+	// Key identifies the progressive release. Cohorts are reshuffled for
+	// each key, so the same devices are not always the first to update.
+	Key string
+}
+
+// Bucket returns the deterministic position of a cohort within the progressive
+// release, in the range [0, 100). The same cohort key always has the same
+// position for a given progressive release key.
+func (p Progressive) Bucket(cohortKey string) float64 {
+	sum := sha256.Sum256([]byte(p.Key + "\x00" + cohortKey))
+	n := binary.BigEndian.Uint64(sum[:8])
+	return float64(n%10000) / 100
+}
+
+// Includes returns true if the cohort gets the new revision.
+func (p Progressive) Includes(cohortKey string) bool {
+	return p.Bucket(cohortKey) < p.Percentage
+}
+
+// RevisionFor returns the revision served to the cohort by the release,
+// taking any progressive release into account. Devices without a cohort key
+// are not part of the progressive release and get the current revision.
+func (r Release) RevisionFor(cohortKey string) int {
+	if r.Progressive != nil && cohortKey != "" && r.Progressive.Includes(cohortKey) {
+		return r.Progressive.Revision
+	}
+	return r.Revision
+}
@@ -0,0 +1,92 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package channel_test
+
+import (
+	"fmt"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/systems/channel"
+)
+
+type progressiveSuite struct{}
+
+var _ = gc.Suite(&progressiveSuite{})
+
+func (s *progressiveSuite) TestBucketDeterministic(c *gc.C) {
+	p := channel.Progressive{Revision: 2, Percentage: 50, Key: "release-1"}
+	for i := 0; i < 100; i++ {
+		key := fmt.Sprintf("machine-%d", i)
+		bucket := p.Bucket(key)
+		c.Check(bucket >= 0 && bucket < 100, jc.IsTrue)
+		c.Check(p.Bucket(key), gc.Equals, bucket)
+	}
+	// A different release key reshuffles the cohorts.
+	other := channel.Progressive{Revision: 2, Percentage: 50, Key: "release-2"}
+	c.Check(other.Bucket("machine-0"), gc.Not(gc.Equals), p.Bucket("machine-0"))
+}
+
+func (s *progressiveSuite) TestDistribution(c *gc.C) {
+	const cohorts = 10000
+	for _, percentage := range []float64{0, 10, 25, 50, 90, 100} {
+		p := channel.Progressive{Revision: 2, Percentage: percentage, Key: "release"}
+		included := 0
+		for i := 0; i < cohorts; i++ {
+			if p.Includes(fmt.Sprintf("machine-%d", i)) {
+				included++
+			}
+		}
+		actual := float64(included) * 100 / cohorts
+		c.Check(actual >= percentage-2 && actual <= percentage+2, jc.IsTrue,
+			gc.Commentf("expected %g%%, got %g%%", percentage, actual))
+	}
+}
+
+func (s *progressiveSuite) TestResolve(c *gc.C) {
+	r := release("2.0/stable", 10)
+	r.Progressive = &channel.Progressive{Revision: 11, Percentage: 30, Key: "rollout"}
+	m := channel.ChannelMap{r}
+
+	// Find one cohort on each side of the rollout.
+	var in, out string
+	for i := 0; in == "" || out == ""; i++ {
+		key := fmt.Sprintf("machine-%d", i)
+		if r.Progressive.Includes(key) {
+			in = key
+		} else {
+			out = key
+		}
+	}
+
+	res, err := m.Resolve(mustParse(c, "2.0/stable"), channel.ResolveOptions{CohortKey: in})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res.Release.Revision, gc.Equals, 11)
+	c.Check(res.Release.Progressive, gc.IsNil)
+	c.Check(res.String(), gc.Equals, "2.0/stable is open at revision 10, cohort in progressive release of revision 11 to 30%")
+	c.Check(r.RevisionFor(in), gc.Equals, 11)
+
+	res, err = m.Resolve(mustParse(c, "2.0/stable"), channel.ResolveOptions{CohortKey: out})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res.Release.Revision, gc.Equals, 10)
+	c.Check(res.Release.Progressive, gc.IsNil)
+	c.Check(res.String(), gc.Equals, "2.0/stable is open at revision 10, cohort not in progressive release of revision 11 to 30%")
+	c.Check(r.RevisionFor(out), gc.Equals, 10)
+
+	// Without a cohort key the current revision is served.
+	res, err = m.Resolve(mustParse(c, "2.0/stable"), channel.ResolveOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res.Release.Revision, gc.Equals, 10)
+	c.Check(res.Release.RevisionFor(""), gc.Equals, 10)
+}
+
+func (s *progressiveSuite) TestRevisionForEmptyCohortKey(c *gc.C) {
+	r := release("2.0/stable", 10)
+	// Every cohort is included in a full rollout, except devices without
+	// a cohort key.
+	r.Progressive = &channel.Progressive{Revision: 11, Percentage: 100, Key: "rollout"}
+	c.Check(r.RevisionFor("machine-0"), gc.Equals, 11)
+	c.Check(r.RevisionFor(""), gc.Equals, 10)
+}
@@ -0,0 +1,159 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package channel
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Errors returned by PlanPromotion for invalid moves.
+var (
+	ErrRevisionNotReleased = errors.New("revision not released")
+	ErrCrossTrackPromotion = errors.New("cannot promote across tracks")
+	ErrPromotionToBranch   = errors.New("cannot promote into a branch")
+	ErrNotAPromotion       = errors.New("target risk is less stable")
+)
+
+// OperationKind is the kind of a release operation.
+type OperationKind string
+
+// Kinds of release operations.
+const (
+	OperationRelease OperationKind = "release"
+	OperationClose   OperationKind = "close"
+)
+
+// Operation is a single store operation of a promotion plan.
+type Operation struct {
+	Kind     OperationKind
+	Channel  Channel
+	Revision int
+}
+
+// String returns the operation, e.g. "release 12 to 2.0/beta".
+func (op Operation) String() string {
+	if op.Kind == OperationClose {
+		return fmt.Sprintf("close %s", op.Channel.Full())
+	}
+	return fmt.Sprintf("release %d to %s", op.Revision, op.Channel.Full())
+}
+
+// PromotionPlan is the ordered list of operations promoting a revision.
+type PromotionPlan struct {
+	Operations []Operation
+	// Opened holds the channels that are closed or have no release, and
+	// that the plan releases to.
+	Opened []Channel
+	// Closed holds the channels that the plan closes.
+	Closed []Channel
+}
+
+// PromotionOptions holds the options used to plan a promotion.
+type PromotionOptions struct {
+	// Direct releases to the target risk only, rather than to every
+	// intermediate risk.
+	Direct bool
+	// CloseBranch closes the branch the revision is promoted from, if any.
+	CloseBranch bool
+	// InferTrack promotes on the track the revision is released to when
+	// the target has no track. Otherwise a target without a track is on
+	// the latest track, as with any cleaned channel. Use ParseVerbatim to
+	// keep an explicit latest track in the target.
+	InferTrack bool
+}
+
+// PlanPromotion returns the operations needed to promote the revision to
+// the target channel, which must not be on a branch. The revision is
+// promoted from the most stable channel it is released to on the target
+// track, through every intermediate risk unless opts.Direct is set. When
+// opts.InferTrack is set and the target has no track, the track the revision
+// is released to is used. The plan is empty if the revision is already
+// released to the target.
+func PlanPromotion(m ChannelMap, revision int, target Channel, opts PromotionOptions) (PromotionPlan, error) {
+	if target.Branch != "" {
+		return PromotionPlan{}, fmt.Errorf("%s: %w", target.Full(), ErrPromotionToBranch)
+	}
+	if err := target.Risk.Validate(); err != nil {
+		return PromotionPlan{}, err
+	}
+
+	source, err := m.promotionSource(revision, target, opts.InferTrack && target.Track == "")
+	if err != nil {
+		return PromotionPlan{}, err
+	}
+	target = Channel{Track: source.Track, Risk: target.Risk}.Clean()
+	if !target.Risk.IsAtLeastAsStable(source.Risk) {
+		return PromotionPlan{}, fmt.Errorf("revision %d from %s to %s: %w", revision, source.Full(), target.Full(), ErrNotAPromotion)
+	}
+
+	// A revision on a branch is first released to the risk of the branch.
+	risk := source.Risk
+	if source.Branch == "" {
+		risk = risk.Next()
+	}
+	if opts.Direct && target.Risk.IsAtLeastAsStable(risk) {
+		risk = target.Risk
+	}
+	var plan PromotionPlan
+	for ; risk != Unknown && target.Risk.IsAtLeastAsStable(risk); risk = risk.Next() {
+		ch := Channel{Track: target.Track, Risk: risk}.Clean()
+		plan.Operations = append(plan.Operations, Operation{
+			Kind:     OperationRelease,
+			Channel:  ch,
+			Revision: revision,
+		})
+		if !m.isOpen(ch) {
+			plan.Opened = append(plan.Opened, ch)
+		}
+	}
+	if opts.CloseBranch && source.Branch != "" {
+		plan.Operations = append(plan.Operations, Operation{Kind: OperationClose, Channel: source})
+		plan.Closed = append(plan.Closed, source)
+	}
+	return plan, nil
+}
+
+// promotionSource returns the most stable channel the revision is released
+// to on the target track, or on any track if the track is inferred.
+func (m ChannelMap) promotionSource(revision int, target Channel, inferred bool) (Channel, error) {
+	var (
+		source Channel
+		found  bool
+		track  = target.Clean().Track
+		tracks = make(map[string]bool)
+	)
+	for _, r := range m {
+		if r.Revision != revision || r.Closed {
+			continue
+		}
+		ch := r.Channel.Clean()
+		tracks[ch.Track] = true
+		if !inferred && ch.Track != track {
+			continue
+		}
+		if !found || ch.Risk.Compare(source.Risk) < 0 || (ch.Risk == source.Risk && ch.Branch == "") {
+			source, found = ch, true
+		}
+	}
+	switch {
+	case len(tracks) == 0:
+		return Empty, fmt.Errorf("revision %d: %w", revision, ErrRevisionNotReleased)
+	case !found:
+		return Empty, fmt.Errorf("revision %d to %s: %w", revision, target.Full(), ErrCrossTrackPromotion)
+	case inferred && len(tracks) > 1:
+		return Empty, fmt.Errorf("revision %d is released to several tracks: %w", revision, ErrCrossTrackPromotion)
+	}
+	return source, nil
+}
+
+// isOpen returns true if an open release is published exactly to ch.
+func (m ChannelMap) isOpen(ch Channel) bool {
+	for _, r := range m {
+		if r.Channel.Clean() == ch && !r.Closed {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,187 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package channel_test
+
+import (
+	"errors"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/systems/channel"
+)
+
+type promotionSuite struct{}
+
+var _ = gc.Suite(&promotionSuite{})
+
+var promotionChannelMap = channel.ChannelMap{
+	release("latest/stable", 10),
+	closed("latest/candidate"),
+	release("latest/beta", 11),
+	release("latest/edge", 12),
+	release("latest/candidate/hotfix", 13),
+	release("2.0/stable", 20),
+	release("2.0/edge", 21),
+	release("3.0/edge", 21),
+	release("4.0/edge", 40),
+}
+
+func (s *promotionSuite) TestPlanPromotion(c *gc.C) {
+	tests := []struct {
+		revision int
+		target   string
+		opts     channel.PromotionOptions
+		ops      []string
+		opened   []string
+		closed   []string
+	}{{
+		revision: 12,
+		target:   "stable",
+		ops:      []string{"release 12 to latest/beta", "release 12 to latest/candidate", "release 12 to latest/stable"},
+		opened:   []string{"latest/candidate"},
+	}, {
+		revision: 12,
+		target:   "beta",
+		ops:      []string{"release 12 to latest/beta"},
+	}, {
+		revision: 12,
+		target:   "stable",
+		opts:     channel.PromotionOptions{Direct: true},
+		ops:      []string{"release 12 to latest/stable"},
+	}, {
+		revision: 11,
+		target:   "beta",
+	}, {
+		revision: 13,
+		target:   "stable",
+		ops:      []string{"release 13 to latest/candidate", "release 13 to latest/stable"},
+		opened:   []string{"latest/candidate"},
+	}, {
+		revision: 13,
+		target:   "candidate",
+		opts:     channel.PromotionOptions{CloseBranch: true},
+		ops:      []string{"release 13 to latest/candidate", "close latest/candidate/hotfix"},
+		opened:   []string{"latest/candidate"},
+		closed:   []string{"latest/candidate/hotfix"},
+	}, {
+		revision: 21,
+		target:   "2.0/candidate",
+		ops:      []string{"release 21 to 2.0/beta", "release 21 to 2.0/candidate"},
+		opened:   []string{"2.0/beta", "2.0/candidate"},
+	}, {
+		revision: 40,
+		target:   "beta",
+		opts:     channel.PromotionOptions{InferTrack: true},
+		ops:      []string{"release 40 to 4.0/beta"},
+		opened:   []string{"4.0/beta"},
+	}, {
+		revision: 12,
+		target:   "stable",
+		opts:     channel.PromotionOptions{Direct: true, InferTrack: true},
+		ops:      []string{"release 12 to latest/stable"},
+	}}
+	for i, test := range tests {
+		c.Logf("test %d: %d to %s", i, test.revision, test.target)
+		plan, err := channel.PlanPromotion(promotionChannelMap, test.revision, channel.MustParse(test.target), test.opts)
+		c.Assert(err, jc.ErrorIsNil)
+		var ops, opened, closed []string
+		for _, op := range plan.Operations {
+			ops = append(ops, op.String())
+		}
+		for _, ch := range plan.Opened {
+			opened = append(opened, ch.Full())
+		}
+		for _, ch := range plan.Closed {
+			closed = append(closed, ch.Full())
+		}
+		c.Check(ops, jc.DeepEquals, test.ops)
+		c.Check(opened, jc.DeepEquals, test.opened)
+		c.Check(closed, jc.DeepEquals, test.closed)
+	}
+}
+
+func mustParseVerbatim(s string) channel.Channel {
+	ch, err := channel.ParseVerbatim(s)
+	if err != nil {
+		panic(err)
+	}
+	return ch
+}
+
+func (s *promotionSuite) TestPlanPromotionErrors(c *gc.C) {
+	tests := []struct {
+		channelMap channel.ChannelMap
+		revision   int
+		target     channel.Channel
+		opts       channel.PromotionOptions
+		err        error
+		message    string
+	}{{
+		revision: 99,
+		target:   channel.MustParse("stable"),
+		err:      channel.ErrRevisionNotReleased,
+		message:  "revision 99: revision not released",
+	}, {
+		revision: 12,
+		target:   channel.MustParse("2.0/stable"),
+		err:      channel.ErrCrossTrackPromotion,
+		message:  "revision 12 to 2.0/stable: cannot promote across tracks",
+	}, {
+		revision: 21,
+		target:   channel.MustParse("stable"),
+		opts:     channel.PromotionOptions{InferTrack: true},
+		err:      channel.ErrCrossTrackPromotion,
+		message:  "revision 21 is released to several tracks: cannot promote across tracks",
+	}, {
+		revision: 40,
+		target:   channel.MustParse("beta"),
+		err:      channel.ErrCrossTrackPromotion,
+		message:  "revision 40 to latest/beta: cannot promote across tracks",
+	}, {
+		channelMap: channel.ChannelMap{release("2.0/edge", 21)},
+		revision:   21,
+		target:     channel.MustParse("latest/stable"),
+		err:        channel.ErrCrossTrackPromotion,
+		message:    "revision 21 to latest/stable: cannot promote across tracks",
+	}, {
+		channelMap: channel.ChannelMap{release("2.0/edge", 21)},
+		revision:   21,
+		target:     mustParseVerbatim("latest/stable"),
+		opts:       channel.PromotionOptions{InferTrack: true},
+		err:        channel.ErrCrossTrackPromotion,
+		message:    "revision 21 to latest/stable: cannot promote across tracks",
+	}, {
+		revision: 12,
+		target:   channel.MustParse("stable/hotfix"),
+		err:      channel.ErrPromotionToBranch,
+		message:  "latest/stable/hotfix: cannot promote into a branch",
+	}, {
+		revision: 10,
+		target:   channel.MustParse("edge"),
+		err:      channel.ErrNotAPromotion,
+		message:  "revision 10 from latest/stable to latest/edge: target risk is less stable",
+	}, {
+		revision: 13,
+		target:   channel.MustParse("edge"),
+		err:      channel.ErrNotAPromotion,
+		message:  "revision 13 from latest/candidate/hotfix to latest/edge: target risk is less stable",
+	}, {
+		revision: 12,
+		target:   channel.Channel{Track: "2.0"},
+		message:  `invalid risk: ""`,
+	}}
+	for i, test := range tests {
+		c.Logf("test %d: %d to %s", i, test.revision, test.target.Full())
+		m := test.channelMap
+		if m == nil {
+			m = promotionChannelMap
+		}
+		_, err := channel.PlanPromotion(m, test.revision, test.target, test.opts)
+		c.Check(err, gc.ErrorMatches, test.message)
+		if test.err != nil {
+			c.Check(errors.Is(err, test.err), jc.IsTrue)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package channel
+
+import (
+	"fmt"
+)
+
+// ResolvedChannel is the result of resolving a new channel wrt a current
+// channel or a pinned track.
+type ResolvedChannel struct {
+	// Channel is the resolved channel. It is not normalized, so that
+	// Format(FormatVerbatim) gives back the components as requested.
+	Channel Channel
+	// InheritedTrack is true when the track of the resolved channel was
+	// inherited from the current channel or the pinned track.
+	InheritedTrack bool
+	// InheritedChannel is true when no new channel was given and the
+	// current channel or pinned track was kept as is.
+	InheritedChannel bool
+}
+
+// String describes which components were inherited.
+func (r ResolvedChannel) String() string {
+	name := r.Channel.Format(FormatVerbatim)
+	switch {
+	case r.InheritedChannel:
+		return fmt.Sprintf("%s (kept)", name)
+	case r.InheritedTrack:
+		return fmt.Sprintf("%s (track %s inherited)", name, r.Channel.Track)
+	default:
+		return name
+	}
+}
+
+// ResolveChannel resolves newChannel wrt channel: if newChannel is
+// risk/branch only, it inherits the track of channel. Either channel may be
+// Empty, but a non empty channel must be valid.
+func ResolveChannel(channel, newChannel Channel) (ResolvedChannel, error) {
+	if err := validateUnlessEmpty(channel); err != nil {
+		return ResolvedChannel{}, err
+	}
+	if err := validateUnlessEmpty(newChannel); err != nil {
+		return ResolvedChannel{}, err
+	}
+	if newChannel == Empty {
+		return ResolvedChannel{Channel: channel, InheritedChannel: channel != Empty}, nil
+	}
+	return inheritTrack(channel.Track, newChannel), nil
+}
+
+// ResolvePinnedChannel resolves newChannel wrt a pinned track, which must be
+// a track only channel. newChannel can only be risk/branch-only or have the
+// same track, otherwise ErrPinnedTrackSwitch is returned. Either channel may
+// be Empty, meaning no track is pinned or no new channel is requested.
+func ResolvePinnedChannel(track, newChannel Channel) (ResolvedChannel, error) {
+	if track != Empty && (track.Validate() != nil || !track.VerbatimTrackOnly()) {
+		return ResolvedChannel{}, fmt.Errorf("invalid pinned track: %s", track.Format(FormatVerbatim))
+	}
+	if err := validateUnlessEmpty(newChannel); err != nil {
+		return ResolvedChannel{}, err
+	}
+	if track == Empty {
+		return ResolvedChannel{Channel: newChannel}, nil
+	}
+	if newChannel == Empty {
+		return ResolvedChannel{Channel: track, InheritedChannel: true}, nil
+	}
+	resolved := inheritTrack(track.Track, newChannel)
+	if resolved.Channel.Track != track.Track {
+		// the track is pinned
+		return ResolvedChannel{}, ErrPinnedTrackSwitch
+	}
+	return resolved, nil
+}
+
+// inheritTrack gives a risk/branch only channel the track, if any.
+func inheritTrack(track string, newChannel Channel) ResolvedChannel {
+	if newChannel.Track == "" && newChannel.Risk != "" && track != "" {
+		return ResolvedChannel{
+			Channel: Channel{
+				Track:  track,
+				Risk:   newChannel.Risk,
+				Branch: newChannel.Branch,
+			},
+			InheritedTrack: true,
+		}
+	}
+	return ResolvedChannel{Channel: newChannel}
+}
+
+func validateUnlessEmpty(c Channel) error {
+	if c == Empty {
+		return nil
+	}
+	return c.Validate()
+}
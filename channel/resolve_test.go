@@ -0,0 +1,80 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package channel_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/systems/channel"
+)
+
+type resolveSuite struct{}
+
+var _ = gc.Suite(&resolveSuite{})
+
+func (s *resolveSuite) TestResolveChannel(c *gc.C) {
+	tests := []struct {
+		channel string
+		new     string
+		result  string
+		desc    string
+	}{
+		{"", "edge", "edge", "edge"},
+		{"stable", "", "stable", "stable (kept)"},
+		{"track", "beta", "track/beta", "track/beta (track track inherited)"},
+		{"track/stable", "stable/branch", "track/stable/branch", "track/stable/branch (track track inherited)"},
+		{"track1/stable", "track2/stable", "track2/stable", "track2/stable"},
+		{"latest/stable", "edge", "latest/edge", "latest/edge (track latest inherited)"},
+	}
+	for _, t := range tests {
+		var ch, newCh channel.Channel
+		if t.channel != "" {
+			ch = verbatim(c, t.channel)
+		}
+		if t.new != "" {
+			newCh = verbatim(c, t.new)
+		}
+		res, err := channel.ResolveChannel(ch, newCh)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(res.Channel.Format(channel.FormatVerbatim), gc.Equals, t.result)
+		c.Check(res.String(), gc.Equals, t.desc)
+	}
+}
+
+func (s *resolveSuite) TestResolveChannelValidates(c *gc.C) {
+	_, err := channel.ResolveChannel(channel.Channel{Track: "2.0", Risk: "bogus"}, verbatim(c, "edge"))
+	c.Check(err, gc.ErrorMatches, `invalid risk in channel: "bogus"`)
+	_, err = channel.ResolveChannel(verbatim(c, "2.0"), channel.Channel{Track: "x/y", Risk: channel.Edge})
+	c.Check(err, gc.ErrorMatches, `invalid track in channel: "x/y"`)
+
+	// The string functions now validate the new channel too.
+	_, err = channel.Resolve("2.0", "bogus/x/y/z")
+	c.Check(err, gc.ErrorMatches, "channel name has too many components: bogus/x/y/z")
+	_, err = channel.ResolvePinned("2.0", "bogus/x/y/z")
+	c.Check(err, gc.ErrorMatches, "channel name has too many components: bogus/x/y/z")
+}
+
+func (s *resolveSuite) TestResolvePinnedChannel(c *gc.C) {
+	res, err := channel.ResolvePinnedChannel(verbatim(c, "2.0"), verbatim(c, "edge/fix"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res, jc.DeepEquals, channel.ResolvedChannel{
+		Channel:        channel.Channel{Track: "2.0", Risk: channel.Edge, Branch: "fix"},
+		InheritedTrack: true,
+	})
+
+	res, err = channel.ResolvePinnedChannel(verbatim(c, "2.0"), channel.Empty)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res.String(), gc.Equals, "2.0 (kept)")
+
+	res, err = channel.ResolvePinnedChannel(channel.Empty, verbatim(c, "3.0/stable"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res.String(), gc.Equals, "3.0/stable")
+
+	_, err = channel.ResolvePinnedChannel(verbatim(c, "2.0"), verbatim(c, "3.0/stable"))
+	c.Check(err, gc.Equals, channel.ErrPinnedTrackSwitch)
+
+	_, err = channel.ResolvePinnedChannel(verbatim(c, "2.0/stable"), verbatim(c, "edge"))
+	c.Check(err, gc.ErrorMatches, "invalid pinned track: 2.0/stable")
+}
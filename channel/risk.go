@@ -0,0 +1,78 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package channel
+
+import (
+	"fmt"
+)
+
+// AllRisks returns the well-known risks, ordered from the most to the least
+// stable.
+func AllRisks() []Risk {
+	return []Risk{Stable, Candidate, Beta, Edge}
+}
+
+// ParseRisk parses a string representing a well-known risk.
+func ParseRisk(s string) (Risk, error) {
+	r := Risk(s)
+	if err := r.Validate(); err != nil {
+		return Unknown, err
+	}
+	return r, nil
+}
+
+// Validate returns an error if the risk is not a well-known risk.
+func (r Risk) Validate() error {
+	if _, ok := channelRiskLevels[r]; !ok {
+		return fmt.Errorf("invalid risk: %q", string(r))
+	}
+	return nil
+}
+
+// Level returns the stability level of the risk, starting at 0 for stable
+// and increasing as the risk gets less stable. Unknown risks return -1.
+func (r Risk) Level() int {
+	return riskLevel(r)
+}
+
+// Compare returns an integer comparing the stability of two risks. The result
+// is negative if r is more stable than other, zero if they are the same and
+// positive if r is less stable. Unknown risks are ordered first.
+func (r Risk) Compare(other Risk) int {
+	return r.Level() - other.Level()
+}
+
+// IsAtLeastAsStable returns true if both risks are well-known and r is the
+// same as or more stable than other.
+func (r Risk) IsAtLeastAsStable(other Risk) bool {
+	if r.Validate() != nil || other.Validate() != nil {
+		return false
+	}
+	return r.Level() <= other.Level()
+}
+
+// Next returns the risk a release is promoted to from r, i.e. the next more
+// stable risk. Unknown is returned for stable and unknown risks.
+func (r Risk) Next() Risk {
+	return riskAtLevel(r, -1)
+}
+
+// Prev returns the next less stable risk. Unknown is returned for edge and
+// unknown risks.
+func (r Risk) Prev() Risk {
+	return riskAtLevel(r, 1)
+}
+
+func riskAtLevel(r Risk, offset int) Risk {
+	level := r.Level()
+	if level < 0 {
+		return Unknown
+	}
+	all := AllRisks()
+	level += offset
+	if level < 0 || level >= len(all) {
+		return Unknown
+	}
+	return all[level]
+}
@@ -0,0 +1,96 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package channel_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/systems/channel"
+)
+
+type riskSuite struct{}
+
+var _ = gc.Suite(&riskSuite{})
+
+func (s *riskSuite) TestAllRisks(c *gc.C) {
+	c.Check(channel.AllRisks(), jc.DeepEquals, []channel.Risk{
+		channel.Stable, channel.Candidate, channel.Beta, channel.Edge,
+	})
+	for i, r := range channel.AllRisks() {
+		c.Check(r.Level(), gc.Equals, i)
+	}
+}
+
+func (s *riskSuite) TestParseRisk(c *gc.C) {
+	r, err := channel.ParseRisk("candidate")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(r, gc.Equals, channel.Candidate)
+
+	for _, str := range []string{"", "Stable", "cand", "latest"} {
+		r, err = channel.ParseRisk(str)
+		c.Check(err, gc.ErrorMatches, `invalid risk: ".*"`)
+		c.Check(r, gc.Equals, channel.Unknown)
+	}
+}
+
+func (s *riskSuite) TestValidate(c *gc.C) {
+	c.Check(channel.Edge.Validate(), jc.ErrorIsNil)
+	c.Check(channel.Unknown.Validate(), gc.ErrorMatches, `invalid risk: ""`)
+	c.Check(channel.Risk("bogus").Validate(), gc.ErrorMatches, `invalid risk: "bogus"`)
+}
+
+func (s *riskSuite) TestLevel(c *gc.C) {
+	c.Check(channel.Stable.Level(), gc.Equals, 0)
+	c.Check(channel.Edge.Level(), gc.Equals, 3)
+	c.Check(channel.Unknown.Level(), gc.Equals, -1)
+	c.Check(channel.Risk("bogus").Level(), gc.Equals, -1)
+}
+
+func (s *riskSuite) TestCompare(c *gc.C) {
+	tests := []struct {
+		a, b channel.Risk
+		cmp  int
+	}{
+		{channel.Stable, channel.Stable, 0},
+		{channel.Stable, channel.Edge, -1},
+		{channel.Edge, channel.Stable, 1},
+		{channel.Beta, channel.Candidate, 1},
+		{channel.Unknown, channel.Stable, -1},
+	}
+	for _, t := range tests {
+		cmp := t.a.Compare(t.b)
+		switch {
+		case t.cmp < 0:
+			c.Check(cmp < 0, jc.IsTrue, gc.Commentf("%q vs %q", t.a, t.b))
+		case t.cmp > 0:
+			c.Check(cmp > 0, jc.IsTrue, gc.Commentf("%q vs %q", t.a, t.b))
+		default:
+			c.Check(cmp, gc.Equals, 0, gc.Commentf("%q vs %q", t.a, t.b))
+		}
+	}
+}
+
+func (s *riskSuite) TestIsAtLeastAsStable(c *gc.C) {
+	c.Check(channel.Stable.IsAtLeastAsStable(channel.Edge), jc.IsTrue)
+	c.Check(channel.Beta.IsAtLeastAsStable(channel.Beta), jc.IsTrue)
+	c.Check(channel.Edge.IsAtLeastAsStable(channel.Candidate), jc.IsFalse)
+	c.Check(channel.Unknown.IsAtLeastAsStable(channel.Edge), jc.IsFalse)
+	c.Check(channel.Stable.IsAtLeastAsStable(channel.Unknown), jc.IsFalse)
+}
+
+func (s *riskSuite) TestNextPrev(c *gc.C) {
+	c.Check(channel.Edge.Next(), gc.Equals, channel.Beta)
+	c.Check(channel.Beta.Next(), gc.Equals, channel.Candidate)
+	c.Check(channel.Candidate.Next(), gc.Equals, channel.Stable)
+	c.Check(channel.Stable.Next(), gc.Equals, channel.Unknown)
+
+	c.Check(channel.Stable.Prev(), gc.Equals, channel.Candidate)
+	c.Check(channel.Candidate.Prev(), gc.Equals, channel.Beta)
+	c.Check(channel.Beta.Prev(), gc.Equals, channel.Edge)
+	c.Check(channel.Edge.Prev(), gc.Equals, channel.Unknown)
+
+	c.Check(channel.Unknown.Next(), gc.Equals, channel.Unknown)
+	c.Check(channel.Risk("bogus").Prev(), gc.Equals, channel.Unknown)
+}
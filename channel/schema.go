@@ -0,0 +1,111 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package channel
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/juju/schema"
+)
+
+// ChannelCheck is an additional check applied to a coerced channel by the
+// ChannelChecker.
+type ChannelCheck func(Channel) error
+
+// MatchingConstraint returns a check that the channel satisfies the channel
+// constraint expression.
+func MatchingConstraint(c Constraint) ChannelCheck {
+	return func(ch Channel) error {
+		if !c.Matches(ch) {
+			return fmt.Errorf("channel %q does not match %q", ch.Full(), c)
+		}
+		return nil
+	}
+}
+
+// ChannelChecker returns a schema.Checker that coerces a channel string, e.g.
+// "2.0/stable", or a map with "track", "risk" and "branch" keys into a
+// normalized Channel. A map holding only a "name" is parsed as a string.
+// The checks, if any, are then applied in order. Errors are prefixed with
+// the path of the value being checked.
+func ChannelChecker(checks ...ChannelCheck) schema.Checker {
+	return channelC{checks: checks}
+}
+
+type channelC struct {
+	checks []ChannelCheck
+}
+
+var channelFields = schema.FieldMap(schema.Fields{
+	"name":   schema.String(),
+	"track":  schema.String(),
+	"risk":   schema.String(),
+	"branch": schema.String(),
+}, schema.Defaults{
+	"name":   schema.Omit,
+	"track":  schema.Omit,
+	"risk":   schema.Omit,
+	"branch": schema.Omit,
+})
+
+// Coerce implements schema.Checker.
+func (c channelC) Coerce(v interface{}, path []string) (interface{}, error) {
+	var (
+		ch  Channel
+		err error
+	)
+	switch value := reflect.ValueOf(v); {
+	case v == nil:
+		return nil, fmt.Errorf("%sexpected channel string or map, got nothing", SchemaPathPrefix(path))
+	case value.Kind() == reflect.String:
+		ch, err = Parse(value.String())
+	case value.Kind() == reflect.Map:
+		var fields interface{}
+		if fields, err = channelFields.Coerce(v, path); err != nil {
+			return nil, err
+		}
+		m := fields.(map[string]interface{})
+		str := func(key string) string {
+			s, _ := m[key].(string)
+			return s
+		}
+		ch = Channel{
+			Name:   str("name"),
+			Track:  str("track"),
+			Risk:   Risk(str("risk")),
+			Branch: str("branch"),
+		}
+		if ch.Track == "" && ch.Risk == "" && ch.Branch == "" && ch.Name != "" {
+			ch, err = Parse(ch.Name)
+		} else {
+			ch, err = ch.ValidatedClean()
+		}
+	default:
+		return nil, fmt.Errorf("%sexpected channel string or map, got %T(%#v)", SchemaPathPrefix(path), v, v)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s%v", SchemaPathPrefix(path), err)
+	}
+	for _, check := range c.checks {
+		if err := check(ch); err != nil {
+			return nil, fmt.Errorf("%s%v", SchemaPathPrefix(path), err)
+		}
+	}
+	return ch, nil
+}
+
+// SchemaPathPrefix returns the path of the value being checked by a
+// schema.Checker as the prefix of an error message, following the format of
+// the schema package, e.g. "base.channel: ".
+func SchemaPathPrefix(path []string) string {
+	if len(path) > 0 && path[0] == "." {
+		path = path[1:]
+	}
+	if s := strings.Join(path, ""); s != "" {
+		return s + ": "
+	}
+	return ""
+}
@@ -0,0 +1,84 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package channel_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/systems/channel"
+)
+
+type schemaSuite struct{}
+
+var _ = gc.Suite(&schemaSuite{})
+
+func (s *schemaSuite) TestChannelChecker(c *gc.C) {
+	tests := []struct {
+		value   interface{}
+		channel string
+	}{{
+		value:   "stable",
+		channel: "latest/stable",
+	}, {
+		value:   "2.0/edge/fix",
+		channel: "2.0/edge/fix",
+	}, {
+		value:   map[string]interface{}{"track": "2.0", "risk": "beta"},
+		channel: "2.0/beta",
+	}, {
+		value:   map[interface{}]interface{}{"name": "3.0/candidate"},
+		channel: "3.0/candidate",
+	}}
+	for i, test := range tests {
+		c.Logf("test %d: %v", i, test.value)
+		v, err := channel.ChannelChecker().Coerce(test.value, nil)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(v, jc.DeepEquals, channel.MustParse(test.channel))
+	}
+}
+
+func (s *schemaSuite) TestChannelCheckerErrors(c *gc.C) {
+	tests := []struct {
+		value interface{}
+		err   string
+	}{{
+		value: nil,
+		err:   `charm.channel: expected channel string or map, got nothing`,
+	}, {
+		value: true,
+		err:   `charm.channel: expected channel string or map, got bool\(true\)`,
+	}, {
+		value: "latest/foo",
+		err:   `charm.channel: invalid risk in channel name: latest/foo`,
+	}, {
+		value: map[string]interface{}{"risk": "foo"},
+		err:   `charm.channel: invalid risk in channel: "foo"`,
+	}, {
+		value: map[string]interface{}{"track": 2},
+		err:   `charm.channel.track: expected string, got int\(2\)`,
+	}}
+	for i, test := range tests {
+		c.Logf("test %d: %v", i, test.value)
+		_, err := channel.ChannelChecker().Coerce(test.value, []string{"charm", ".channel"})
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *schemaSuite) TestMatchingConstraint(c *gc.C) {
+	checker := channel.ChannelChecker(channel.MatchingConstraint(channel.MustParseConstraint("3.*/>=candidate")))
+	v, err := checker.Coerce("3.1/stable", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(v, jc.DeepEquals, channel.MustParse("3.1/stable"))
+
+	_, err = checker.Coerce("3.1/edge", []string{"channel"})
+	c.Check(err, gc.ErrorMatches, `channel: channel "3.1/edge" does not match "3.\*/>=candidate"`)
+}
+
+func (s *schemaSuite) TestSchemaPathPrefix(c *gc.C) {
+	c.Check(channel.SchemaPathPrefix(nil), gc.Equals, "")
+	c.Check(channel.SchemaPathPrefix([]string{"."}), gc.Equals, "")
+	c.Check(channel.SchemaPathPrefix([]string{".", "base", ".channel"}), gc.Equals, "base.channel: ")
+	c.Check(channel.SchemaPathPrefix([]string{"bases", "[0]"}), gc.Equals, "bases[0]: ")
+}
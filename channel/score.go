@@ -0,0 +1,107 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package channel
+
+import (
+	"fmt"
+)
+
+// Score describes how well a candidate channel serves a requested channel.
+// It extends Match: Score.Match returns the same Match as Channel.Match for
+// cleaned channels, so the "track:risk" output of Match.String tells whether
+// the track and risk fields of a score are set.
+//
+// Scores are ranked by, in order of precedence:
+//  - Track: the candidate is on the requested track;
+//  - Risk: the candidate is as stable or more stable than requested;
+//  - RiskDistance: the number of risk levels between the two, lower is better;
+//  - Branch: the candidate is on the requested branch, or neither is on a
+//    branch.
+type Score struct {
+	Track        bool
+	Risk         bool
+	RiskDistance int
+	Branch       bool
+}
+
+// Match returns the Match corresponding to the score.
+func (s Score) Match() Match {
+	return Match{Track: s.Track, Risk: s.Risk}
+}
+
+// String returns the score, e.g. "track:risk distance=1 branch".
+func (s Score) String() string {
+	str := fmt.Sprintf("%s distance=%d", s.Match(), s.RiskDistance)
+	if s.Branch {
+		str += " branch"
+	}
+	return str
+}
+
+// Compare returns a positive number if s ranks better than other, a negative
+// number if it ranks worse, and zero if they rank the same.
+func (s Score) Compare(other Score) int {
+	if c := compareBool(s.Track, other.Track); c != 0 {
+		return c
+	}
+	if c := compareBool(s.Risk, other.Risk); c != 0 {
+		return c
+	}
+	if s.RiskDistance != other.RiskDistance {
+		return other.RiskDistance - s.RiskDistance
+	}
+	return compareBool(s.Branch, other.Branch)
+}
+
+func compareBool(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	default:
+		return -1
+	}
+}
+
+// Score returns how well c1 serves c, considering c the requested channel.
+// Both channels are cleaned before being compared.
+func (c *Channel) Score(c1 *Channel) Score {
+	req, cand := c.Clean(), c1.Clean()
+	match := req.Match(&cand)
+	distance := riskLevel(req.Risk) - riskLevel(cand.Risk)
+	if distance < 0 {
+		distance = -distance
+	}
+	return Score{
+		Track:        match.Track,
+		Risk:         match.Risk,
+		RiskDistance: distance,
+		Branch:       req.Branch == cand.Branch,
+	}
+}
+
+// Best returns the candidate that best serves the requested channel, along
+// with its score. Ties are broken deterministically by choosing the candidate
+// with the lowest full name, then the first one given. False is returned if
+// there are no candidates.
+func Best(requested Channel, candidates []Channel) (Channel, Score, bool) {
+	var (
+		best      Channel
+		bestScore Score
+		found     bool
+	)
+	for _, cand := range candidates {
+		cand := cand
+		score := requested.Score(&cand)
+		if found {
+			c := score.Compare(bestScore)
+			if c < 0 || (c == 0 && cand.Full() >= best.Full()) {
+				continue
+			}
+		}
+		best, bestScore, found = cand, score, true
+	}
+	return best, bestScore, found
+}
@@ -0,0 +1,80 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package channel_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/systems/channel"
+)
+
+type scoreSuite struct{}
+
+var _ = gc.Suite(&scoreSuite{})
+
+func (s *scoreSuite) TestScore(c *gc.C) {
+	tests := []struct {
+		req   string
+		c1    string
+		score string
+	}{
+		{"stable", "stable", "track:risk distance=0 branch"},
+		{"stable", "latest/stable", "track:risk distance=0 branch"},
+		{"stable", "beta", "track distance=2 branch"},
+		{"edge", "stable", "track:risk distance=3 branch"},
+		{"1.0/edge", "stable", "risk distance=3 branch"},
+		{"1.0/stable", "2.0/beta", " distance=2 branch"},
+		{"1.0/edge/fix", "1.0/edge/fix", "track:risk distance=0 branch"},
+		{"1.0/edge/fix", "1.0/edge", "track:risk distance=0"},
+		{"1.0/edge", "1.0/beta/fix", "track:risk distance=1"},
+	}
+	for _, t := range tests {
+		req, c1 := mustParse(c, t.req), mustParse(c, t.c1)
+		score := req.Score(&c1)
+		c.Check(score.String(), gc.Equals, t.score, gc.Commentf("%q %q", t.req, t.c1))
+		c.Check(score.Match(), gc.Equals, req.Match(&c1))
+	}
+}
+
+func (s *scoreSuite) TestCompare(c *gc.C) {
+	exact := channel.Score{Track: true, Risk: true, Branch: true}
+	c.Check(exact.Compare(exact), gc.Equals, 0)
+	c.Check(exact.Compare(channel.Score{Track: true, Risk: true}) > 0, jc.IsTrue)
+	c.Check(exact.Compare(channel.Score{Track: true, Risk: true, RiskDistance: 1, Branch: true}) > 0, jc.IsTrue)
+	c.Check(channel.Score{Track: true, Risk: true, RiskDistance: 3}.Compare(channel.Score{Track: true, RiskDistance: 1}) > 0, jc.IsTrue)
+	c.Check(channel.Score{Risk: true}.Compare(channel.Score{Track: true, RiskDistance: 3}) < 0, jc.IsTrue)
+}
+
+func (s *scoreSuite) TestBest(c *gc.C) {
+	parse := func(strs ...string) []channel.Channel {
+		chs := make([]channel.Channel, len(strs))
+		for i, str := range strs {
+			chs[i] = mustParse(c, str)
+		}
+		return chs
+	}
+	tests := []struct {
+		req        string
+		candidates []channel.Channel
+		best       string
+	}{
+		{"stable", parse("stable", "edge", "2.0/stable"), "stable"},
+		{"beta", parse("edge", "stable", "candidate"), "candidate"},
+		{"beta", parse("edge", "2.0/beta"), "edge"},
+		{"2.0/edge/fix", parse("2.0/edge", "2.0/edge/fix", "2.0/beta/fix"), "2.0/edge/fix"},
+		{"2.0/edge/fix", parse("2.0/beta/fix", "2.0/edge"), "2.0/edge"},
+		// Ties are broken by name, regardless of the candidates order.
+		{"2.0/edge", parse("2.0/edge/b", "2.0/edge/a"), "2.0/edge/a"},
+		{"2.0/edge", parse("2.0/edge/a", "2.0/edge/b"), "2.0/edge/a"},
+	}
+	for _, t := range tests {
+		best, _, ok := channel.Best(mustParse(c, t.req), t.candidates)
+		c.Assert(ok, jc.IsTrue)
+		c.Check(best.String(), gc.Equals, t.best, gc.Commentf("%q", t.req))
+	}
+
+	_, _, ok := channel.Best(mustParse(c, "stable"), nil)
+	c.Check(ok, jc.IsFalse)
+}
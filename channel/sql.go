@@ -0,0 +1,95 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package channel
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+var (
+	_ driver.Valuer = Channel{}
+	_ sql.Scanner   = (*Channel)(nil)
+	_ driver.Valuer = NullChannel{}
+	_ sql.Scanner   = (*NullChannel)(nil)
+)
+
+// Value implements driver.Valuer. The channel is stored cleaned, e.g.
+// "stable" rather than "latest/stable", so that equal channels are stored
+// identically, and in the same form as the channel of a stored base.
+func (c Channel) Value() (driver.Value, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return c.Clean().String(), nil
+}
+
+// Scan implements sql.Scanner. It accepts a channel string, as well as the
+// legacy JSON object form, e.g. {"track":"2.0","risk":"stable"}. The scanned
+// channel is normalized.
+func (c *Channel) Scan(src interface{}) error {
+	var s string
+	switch v := src.(type) {
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	case nil:
+		return fmt.Errorf("cannot scan NULL into channel")
+	default:
+		return fmt.Errorf("cannot scan %T into channel", src)
+	}
+	ch, err := parseStored(s)
+	if err != nil {
+		return err
+	}
+	*c = ch
+	return nil
+}
+
+// parseStored parses a channel string or a legacy JSON object.
+func parseStored(s string) (Channel, error) {
+	if !strings.HasPrefix(strings.TrimSpace(s), "{") {
+		return Parse(s)
+	}
+	var ch Channel
+	if err := json.Unmarshal([]byte(s), &ch); err != nil {
+		return Empty, fmt.Errorf("invalid legacy channel: %v", err)
+	}
+	if ch.Track == "" && ch.Risk == "" && ch.Branch == "" && ch.Name != "" {
+		return Parse(ch.Name)
+	}
+	return ch.ValidatedClean()
+}
+
+// NullChannel is a channel that may be NULL, for use with optional columns.
+type NullChannel struct {
+	Channel Channel
+	// Valid is true if Channel is not NULL.
+	Valid bool
+}
+
+// Value implements driver.Valuer.
+func (n NullChannel) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Channel.Value()
+}
+
+// Scan implements sql.Scanner.
+func (n *NullChannel) Scan(src interface{}) error {
+	if src == nil {
+		n.Channel, n.Valid = Empty, false
+		return nil
+	}
+	if err := n.Channel.Scan(src); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
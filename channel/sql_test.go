@@ -0,0 +1,96 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package channel_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/systems/channel"
+)
+
+type sqlSuite struct{}
+
+var _ = gc.Suite(&sqlSuite{})
+
+func (s *sqlSuite) TestValue(c *gc.C) {
+	v, err := channel.MustParse("stable").Value()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(v, gc.Equals, "stable")
+
+	verbatim, err := channel.ParseVerbatim("latest/stable")
+	c.Assert(err, jc.ErrorIsNil)
+	v, err = verbatim.Value()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(v, gc.Equals, "stable")
+
+	v, err = channel.MustParse("2.0/edge/fix").Value()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(v, gc.Equals, "2.0/edge/fix")
+
+	_, err = channel.Empty.Value()
+	c.Check(err, gc.ErrorMatches, "channel cannot be empty")
+}
+
+func (s *sqlSuite) TestScan(c *gc.C) {
+	tests := []struct {
+		src     interface{}
+		channel string
+		err     string
+	}{{
+		src:     "latest/stable",
+		channel: "stable",
+	}, {
+		src:     []byte("2.0/beta"),
+		channel: "2.0/beta",
+	}, {
+		src:     `{"name":"2.0/edge","track":"2.0","risk":"edge"}`,
+		channel: "2.0/edge",
+	}, {
+		src:     []byte(`{"name":"candidate/fix"}`),
+		channel: "candidate/fix",
+	}, {
+		src: nil,
+		err: "cannot scan NULL into channel",
+	}, {
+		src: 1.5,
+		err: "cannot scan float64 into channel",
+	}, {
+		src: "a/b/c/d",
+		err: "channel name has too many components: a/b/c/d",
+	}, {
+		src: `{"risk":"foo"}`,
+		err: `invalid risk in channel: "foo"`,
+	}, {
+		src: `{"risk":`,
+		err: "invalid legacy channel: unexpected end of JSON input",
+	}}
+	for i, test := range tests {
+		c.Logf("test %d: %v", i, test.src)
+		var ch channel.Channel
+		err := ch.Scan(test.src)
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			continue
+		}
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(ch, jc.DeepEquals, channel.MustParse(test.channel))
+	}
+}
+
+func (s *sqlSuite) TestNullChannel(c *gc.C) {
+	var n channel.NullChannel
+	v, err := n.Value()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(v, gc.IsNil)
+
+	c.Assert(n.Scan("edge"), jc.ErrorIsNil)
+	c.Check(n, jc.DeepEquals, channel.NullChannel{Channel: channel.MustParse("edge"), Valid: true})
+	v, err = n.Value()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(v, gc.Equals, "edge")
+
+	c.Assert(n.Scan(nil), jc.ErrorIsNil)
+	c.Check(n, jc.DeepEquals, channel.NullChannel{})
+}
@@ -0,0 +1,108 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/systems/channel"
+)
+
+const resolveDoc = `
+Resolves a new channel against the current channel, as a refresh does: a
+risk or risk/branch only channel keeps the track of the current channel.
+
+With --pinned, the first argument is a pinned track instead of a channel,
+and resolving to a channel on a different track fails.
+
+Examples:
+    juju-base channel resolve 2.0/stable edge
+    juju-base channel resolve --pinned 2.0 candidate/hotfix
+`
+
+func newChannelCommand() *cmd.SuperCommand {
+	ch := cmd.NewSuperCommand(cmd.SuperCommandParams{
+		Name:        "channel",
+		UsagePrefix: "juju-base",
+		Purpose:     "Inspect channels.",
+	})
+	ch.Register(&resolveCommand{})
+	return ch
+}
+
+type resolveCommand struct {
+	cmd.CommandBase
+	out        cmd.Output
+	pinned     bool
+	current    string
+	newChannel string
+}
+
+// resolution is the structured output of the resolve command.
+type resolution struct {
+	Current  string `yaml:"current,omitempty" json:"current,omitempty"`
+	Pinned   string `yaml:"pinned,omitempty" json:"pinned,omitempty"`
+	New      string `yaml:"new" json:"new"`
+	Resolved string `yaml:"resolved" json:"resolved"`
+	Full     string `yaml:"full" json:"full"`
+}
+
+// Info implements cmd.Command.
+func (c *resolveCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "resolve",
+		Args:    "<current-channel|pinned-track> <new-channel>",
+		Purpose: "Resolve a channel against the current channel or a pinned track.",
+		Doc:     resolveDoc,
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *resolveCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "tabular", formatters(formatResolveTabular))
+	f.BoolVar(&c.pinned, "pinned", false, "Resolve against a pinned track")
+}
+
+// Init implements cmd.Command.
+func (c *resolveCommand) Init(args []string) error {
+	if len(args) < 2 {
+		return errors.New("expected current channel and new channel")
+	}
+	c.current, c.newChannel, args = args[0], args[1], args[2:]
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements cmd.Command.
+func (c *resolveCommand) Run(ctx *cmd.Context) error {
+	result := resolution{New: c.newChannel}
+	var err error
+	if c.pinned {
+		result.Pinned = c.current
+		result.Resolved, err = channel.ResolvePinned(c.current, c.newChannel)
+	} else {
+		result.Current = c.current
+		result.Resolved, err = channel.Resolve(c.current, c.newChannel)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if result.Full, err = channel.Full(result.Resolved); err != nil {
+		return errors.Trace(err)
+	}
+	return c.out.Write(ctx, result)
+}
+
+func formatResolveTabular(w io.Writer, value interface{}) error {
+	result, ok := value.(resolution)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", result, value)
+	}
+	_, err := fmt.Fprint(w, result.Full)
+	return err
+}
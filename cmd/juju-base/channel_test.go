@@ -0,0 +1,67 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type resolveSuite struct{}
+
+var _ = gc.Suite(&resolveSuite{})
+
+func (s *resolveSuite) TestInit(c *gc.C) {
+	cmdtesting.TestInit(c, &resolveCommand{}, []string{"stable"}, "expected current channel and new channel")
+	cmdtesting.TestInit(c, &resolveCommand{}, []string{"stable", "edge", "beta"}, `unrecognized args: \["beta"\]`)
+}
+
+func (s *resolveSuite) TestResolve(c *gc.C) {
+	tests := []struct {
+		args     []string
+		resolved string
+	}{
+		{[]string{"2.0/stable", "edge"}, "2.0/edge"},
+		{[]string{"2.0/stable", "3.0/beta"}, "3.0/beta"},
+		{[]string{"stable", "edge/fix"}, "latest/edge/fix"},
+		{[]string{"--pinned", "2.0", "candidate"}, "2.0/candidate"},
+		{[]string{"--pinned", "2.0", "2.0/edge"}, "2.0/edge"},
+	}
+	for i, test := range tests {
+		c.Logf("test %d: %v", i, test.args)
+		ctx, err := cmdtesting.RunCommand(c, &resolveCommand{}, test.args...)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(cmdtesting.Stdout(ctx), gc.Equals, test.resolved+"\n")
+	}
+}
+
+func (s *resolveSuite) TestResolveFormats(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, &resolveCommand{}, "2.0/stable", "edge", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+current: 2.0/stable
+new: edge
+resolved: 2.0/edge
+full: 2.0/edge
+`[1:])
+
+	ctx, err = cmdtesting.RunCommand(c, &resolveCommand{}, "--pinned", "2.0", "edge", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `{"pinned":"2.0","new":"edge","resolved":"2.0/edge","full":"2.0/edge"}`+"\n")
+}
+
+func (s *resolveSuite) TestResolveErrors(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, &resolveCommand{}, "--pinned", "2.0", "3.0/edge")
+	c.Check(err, gc.ErrorMatches, "cannot switch pinned track")
+
+	_, err = cmdtesting.RunCommand(c, &resolveCommand{}, "stable", "a/b/c/d")
+	c.Check(err, gc.ErrorMatches, "channel name has too many components: a/b/c/d")
+}
+
+func (s *resolveSuite) TestSuperCommand(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, NewSuperCommand(), "channel", "resolve", "2.0/stable", "beta")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "2.0/beta\n")
+}
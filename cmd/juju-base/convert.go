@@ -0,0 +1,107 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+)
+
+const convertDoc = `
+Converts a base between its legacy series, os@version and os/track/risk
+forms. The input can be given in any form. Converting to os@version drops
+the risk and branch of the base; converting to a series fails for bases that
+have no legacy series.
+
+Examples:
+    juju-base convert focal
+    juju-base convert ubuntu/18.04/stable --to series
+    juju-base convert bionic --to version --format json
+`
+
+// Conversion targets.
+const (
+	formSeries  = "series"
+	formVersion = "version"
+	formBase    = "base"
+)
+
+type convertCommand struct {
+	cmd.CommandBase
+	out   cmd.Output
+	to    string
+	input string
+}
+
+// conversion is the structured output of the convert command.
+type conversion struct {
+	Input  string `yaml:"input" json:"input"`
+	Form   string `yaml:"form" json:"form"`
+	Output string `yaml:"output" json:"output"`
+}
+
+// Info implements cmd.Command.
+func (c *convertCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "convert",
+		Args:    "<base>",
+		Purpose: "Convert a base between series, os@version and os/track/risk.",
+		Doc:     convertDoc,
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *convertCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "tabular", formatters(formatConvertTabular))
+	f.StringVar(&c.to, "to", formBase, "The form to convert to: series, version or base")
+}
+
+// Init implements cmd.Command.
+func (c *convertCommand) Init(args []string) error {
+	switch c.to {
+	case formSeries, formVersion, formBase:
+	default:
+		return errors.NotValidf("--to %q", c.to)
+	}
+	if len(args) == 0 {
+		return errors.New("no base specified")
+	}
+	c.input, args = args[0], args[1:]
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements cmd.Command.
+func (c *convertCommand) Run(ctx *cmd.Context) error {
+	base, _, err := parseInput(c.input)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	result := conversion{Input: c.input, Form: c.to}
+	switch c.to {
+	case formSeries:
+		series, ok := seriesOf(base)
+		if !ok {
+			return errors.NotFoundf("series for base %q", fullOf(base))
+		}
+		result.Output = series
+	case formVersion:
+		result.Output = versionOf(base)
+	case formBase:
+		result.Output = fullOf(base)
+	}
+	return c.out.Write(ctx, result)
+}
+
+func formatConvertTabular(w io.Writer, value interface{}) error {
+	result, ok := value.(conversion)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", result, value)
+	}
+	_, err := fmt.Fprint(w, result.Output)
+	return err
+}
@@ -0,0 +1,55 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type convertSuite struct{}
+
+var _ = gc.Suite(&convertSuite{})
+
+func (s *convertSuite) TestInit(c *gc.C) {
+	cmdtesting.TestInit(c, &convertCommand{}, nil, "no base specified")
+	cmdtesting.TestInit(c, &convertCommand{}, []string{"--to", "foo", "focal"}, `--to "foo" not valid`)
+}
+
+func (s *convertSuite) TestConvert(c *gc.C) {
+	tests := []struct {
+		args   []string
+		output string
+	}{
+		{[]string{"focal"}, "ubuntu/20.04/stable"},
+		{[]string{"focal", "--to", "version"}, "ubuntu@20.04"},
+		{[]string{"ubuntu@18.04", "--to", "series"}, "bionic"},
+		{[]string{"ubuntu@18.04"}, "ubuntu/18.04/stable"},
+		{[]string{"ubuntu/16.04/stable", "--to", "series"}, "xenial"},
+		{[]string{"ubuntu/16.04/edge", "--to", "version"}, "ubuntu@16.04"},
+		{[]string{"opensuseleap", "--to", "series"}, "opensuse42"},
+	}
+	for i, test := range tests {
+		c.Logf("test %d: %v", i, test.args)
+		ctx, err := cmdtesting.RunCommand(c, &convertCommand{}, test.args...)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(cmdtesting.Stdout(ctx), gc.Equals, test.output+"\n")
+	}
+}
+
+func (s *convertSuite) TestConvertFormats(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, &convertCommand{}, "bionic", "--to", "version", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `{"input":"bionic","form":"version","output":"ubuntu@18.04"}`+"\n")
+
+	ctx, err = cmdtesting.RunCommand(c, &convertCommand{}, "bionic", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "input: bionic\nform: base\noutput: ubuntu/18.04/stable\n")
+}
+
+func (s *convertSuite) TestConvertNoSeries(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, &convertCommand{}, "ubuntu/20.04/edge", "--to", "series")
+	c.Check(err, gc.ErrorMatches, `series for base "ubuntu/20.04/edge" not found`)
+}
@@ -0,0 +1,148 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/juju/clock"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/systems"
+	"github.com/juju/systems/channel"
+)
+
+const listDoc = `
+Lists the known bases along with their lifecycle data. Bases without an
+announced end of life are reported as supported.
+
+Examples:
+    juju-base list
+    juju-base list --os ubuntu --status supported
+    juju-base list --format yaml
+`
+
+// Lifecycle statuses.
+const (
+	statusSupported = "supported"
+	statusEndOfLife = "end-of-life"
+)
+
+type listCommand struct {
+	cmd.CommandBase
+	out    cmd.Output
+	clock  clock.Clock
+	os     string
+	status string
+}
+
+// listEntry is the structured output of a known base.
+type listEntry struct {
+	Series    string `yaml:"series" json:"series"`
+	OS        string `yaml:"os" json:"os"`
+	Version   string `yaml:"version" json:"version"`
+	Base      string `yaml:"base" json:"base"`
+	EndOfLife string `yaml:"end-of-life,omitempty" json:"end-of-life,omitempty"`
+	Status    string `yaml:"status" json:"status"`
+}
+
+// Info implements cmd.Command.
+func (c *listCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "list",
+		Purpose: "List the known bases and their lifecycle.",
+		Doc:     listDoc,
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *listCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "tabular", formatters(formatListTabular))
+	f.StringVar(&c.os, "os", "", "Only list bases of the given OS")
+	f.StringVar(&c.status, "status", "", "Only list bases with the given status: supported or end-of-life")
+}
+
+// Init implements cmd.Command.
+func (c *listCommand) Init(args []string) error {
+	switch c.status {
+	case "", statusSupported, statusEndOfLife:
+	default:
+		return errors.NotValidf("--status %q", c.status)
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements cmd.Command.
+func (c *listCommand) Run(ctx *cmd.Context) error {
+	now := c.clock.Now()
+	type known struct {
+		base  systems.Base
+		entry listEntry
+	}
+	var bases []known
+	for _, series := range systems.KnownSeries() {
+		base, err := systems.ParseBaseFromSeries(series)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if c.os != "" && base.Name != c.os {
+			continue
+		}
+		entry := listEntry{
+			Series:  series,
+			OS:      base.Name,
+			Version: newChannelInfo(base.Channel).Track,
+			Base:    fullOf(base),
+			Status:  statusSupported,
+		}
+		if eol, ok := base.EndOfLife(); ok {
+			entry.EndOfLife = eol.Format("2006-01-02")
+			if !now.Before(eol) {
+				entry.Status = statusEndOfLife
+			}
+		}
+		if c.status != "" && entry.Status != c.status {
+			continue
+		}
+		bases = append(bases, known{base: base, entry: entry})
+	}
+	sort.SliceStable(bases, func(i, j int) bool {
+		a, b := bases[i].base, bases[j].base
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return channel.CompareTracks(a.Channel.Track, b.Channel.Track) < 0
+	})
+
+	entries := []listEntry{}
+	for _, b := range bases {
+		entries = append(entries, b.entry)
+	}
+	return c.out.Write(ctx, entries)
+}
+
+func formatListTabular(w io.Writer, value interface{}) error {
+	entries, ok := value.([]listEntry)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", entries, value)
+	}
+	if len(entries) == 0 {
+		_, err := fmt.Fprint(w, "No bases found.")
+		return err
+	}
+	tw := newTabWriter(w)
+	fmt.Fprintln(tw, "Series\tBase\tVersion\tEnd of life\tStatus")
+	for _, e := range entries {
+		eol := e.EndOfLife
+		if eol == "" {
+			eol = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", e.Series, e.Base, e.Version, eol, e.Status)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,81 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type listSuite struct{}
+
+var _ = gc.Suite(&listSuite{})
+
+var testNow = time.Date(2022, time.June, 1, 0, 0, 0, 0, time.UTC)
+
+func newListCommand() *listCommand {
+	return &listCommand{clock: testclock.NewClock(testNow)}
+}
+
+func (s *listSuite) TestInit(c *gc.C) {
+	cmdtesting.TestInit(c, newListCommand(), []string{"--status", "foo"}, `--status "foo" not valid`)
+	cmdtesting.TestInit(c, newListCommand(), []string{"focal"}, `unrecognized args: \["focal"\]`)
+}
+
+func (s *listSuite) TestListTabular(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, newListCommand(), "--os", "windows", "--status", "supported")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+Series       Base                        Version      End of life  Status
+win10        windows/win10/stable        win10        2025-10-14   supported
+win2012      windows/win2012/stable      win2012      2023-10-10   supported
+win2012hv    windows/win2012hv/stable    win2012hv    2023-10-10   supported
+win2012hvr2  windows/win2012hvr2/stable  win2012hvr2  2023-10-10   supported
+win2012r2    windows/win2012r2/stable    win2012r2    2023-10-10   supported
+win2016      windows/win2016/stable      win2016      2027-01-12   supported
+win2016hv    windows/win2016hv/stable    win2016hv    2027-01-12   supported
+win2016nano  windows/win2016nano/stable  win2016nano  2027-01-12   supported
+win2019      windows/win2019/stable      win2019      2029-01-09   supported
+win81        windows/win81/stable        win81        2023-01-10   supported
+
+`[1:])
+}
+
+func (s *listSuite) TestListJSON(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, newListCommand(), "--os", "ubuntu", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	var entries []listEntry
+	c.Assert(json.Unmarshal([]byte(cmdtesting.Stdout(ctx)), &entries), jc.ErrorIsNil)
+	c.Assert(len(entries) > 2, jc.IsTrue)
+	c.Check(entries[0], jc.DeepEquals, listEntry{
+		Series:    "precise",
+		OS:        "ubuntu",
+		Version:   "12.04",
+		Base:      "ubuntu/12.04/stable",
+		EndOfLife: "2017-04-28",
+		Status:    "end-of-life",
+	})
+	var focal listEntry
+	for _, e := range entries {
+		if e.Series == "focal" {
+			focal = e
+		}
+	}
+	c.Check(focal.Status, gc.Equals, "supported")
+}
+
+func (s *listSuite) TestListEmpty(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, newListCommand(), "--os", "foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "No bases found.\n")
+
+	ctx, err = cmdtesting.RunCommand(c, newListCommand(), "--os", "foo", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "[]\n")
+}
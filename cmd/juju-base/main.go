@@ -0,0 +1,47 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+// The juju-base command inspects and converts bases, legacy series and
+// channels.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/juju/clock"
+	"github.com/juju/cmd"
+)
+
+const baseDoc = `
+juju-base inspects and converts the bases that juju runs workloads on.
+
+A base can be given in any of the following forms:
+  - a legacy series, e.g. "focal";
+  - os@version, e.g. "ubuntu@20.04";
+  - os/track/risk[/branch], e.g. "ubuntu/20.04/stable".
+`
+
+// NewSuperCommand returns the juju-base command and its subcommands.
+func NewSuperCommand() *cmd.SuperCommand {
+	base := cmd.NewSuperCommand(cmd.SuperCommandParams{
+		Name:    "juju-base",
+		Purpose: "Inspect and convert bases, series and channels.",
+		Doc:     baseDoc,
+	})
+	base.Register(&parseCommand{})
+	base.Register(&convertCommand{})
+	base.Register(&listCommand{clock: clock.WallClock})
+	base.Register(&validateCommand{clock: clock.WallClock})
+	base.Register(newChannelCommand())
+	return base
+}
+
+func main() {
+	ctx, err := cmd.DefaultContext()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(2)
+	}
+	os.Exit(cmd.Main(NewSuperCommand(), ctx, os.Args[1:]))
+}
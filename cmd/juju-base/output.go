@@ -0,0 +1,92 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/systems"
+	"github.com/juju/systems/channel"
+)
+
+// formatters returns the yaml, json and tabular formatters, the latter
+// being specific to each command.
+func formatters(tabular cmd.Formatter) map[string]cmd.Formatter {
+	return map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": tabular,
+	}
+}
+
+// newTabWriter returns a tabwriter writing aligned columns to w.
+func newTabWriter(w io.Writer) *tabwriter.Writer {
+	return tabwriter.NewWriter(w, 0, 1, 2, ' ', 0)
+}
+
+// channelInfo is the structured output of a channel.
+type channelInfo struct {
+	Track  string `yaml:"track" json:"track"`
+	Risk   string `yaml:"risk" json:"risk"`
+	Branch string `yaml:"branch,omitempty" json:"branch,omitempty"`
+}
+
+func newChannelInfo(ch channel.Channel) channelInfo {
+	ch = ch.Clean()
+	track := ch.Track
+	if track == "" {
+		track = channel.DefaultTrack
+	}
+	return channelInfo{Track: track, Risk: string(ch.Risk), Branch: ch.Branch}
+}
+
+// parseInput parses a base given as a legacy series, as os@version or as
+// os/track/risk[/branch]. The series alias is returned when the input is an
+// alternative series name.
+func parseInput(s string) (systems.Base, *systems.SeriesAlias, error) {
+	series, err := inputSeries(s)
+	if err != nil {
+		return systems.Base{}, nil, errors.Trace(err)
+	}
+	return systems.ParseBaseFromSeriesAlias(series)
+}
+
+// inputSeries returns the series string accepted by the systems package for
+// a base given on the command line. The os@version form, e.g. "ubuntu@20.04",
+// is converted to the equivalent os/track form; other inputs are returned
+// unchanged.
+func inputSeries(s string) (string, error) {
+	i := strings.Index(s, "@")
+	if i < 0 {
+		return s, nil
+	}
+	osName, version := s[:i], s[i+1:]
+	if osName == "" || version == "" || strings.ContainsAny(osName, "/") || strings.ContainsAny(version, "/@") {
+		return "", errors.NotValidf("base %q", s)
+	}
+	return osName + "/" + version, nil
+}
+
+// seriesOf returns the legacy series of the base, if it has one.
+func seriesOf(b systems.Base) (string, bool) {
+	s := b.String()
+	return s, !strings.Contains(s, "/")
+}
+
+// versionOf returns the os@version form of the base, which omits its risk
+// and branch.
+func versionOf(b systems.Base) string {
+	return fmt.Sprintf("%s@%s", b.Name, newChannelInfo(b.Channel).Track)
+}
+
+// fullOf returns the os/track/risk[/branch] form of the base.
+func fullOf(b systems.Base) string {
+	return b.Name + "/" + b.Channel.Format(channel.FormatFull)
+}
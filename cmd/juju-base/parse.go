@@ -0,0 +1,119 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+)
+
+const parseDoc = `
+Shows the structured base for any base input, including legacy series and
+series aliases.
+
+Examples:
+    juju-base parse focal
+    juju-base parse ubuntu@22.04 --format json
+    juju-base parse centos/7/stable --format tabular
+`
+
+type parseCommand struct {
+	cmd.CommandBase
+	out   cmd.Output
+	input string
+}
+
+// baseInfo is the structured output of a base.
+type baseInfo struct {
+	OS         string      `yaml:"os" json:"os"`
+	Channel    channelInfo `yaml:"channel" json:"channel"`
+	Base       string      `yaml:"base" json:"base"`
+	Version    string      `yaml:"version" json:"version"`
+	Series     string      `yaml:"series,omitempty" json:"series,omitempty"`
+	Alias      string      `yaml:"alias,omitempty" json:"alias,omitempty"`
+	EndOfLife  string      `yaml:"end-of-life,omitempty" json:"end-of-life,omitempty"`
+	Deprecated bool        `yaml:"deprecated,omitempty" json:"deprecated,omitempty"`
+}
+
+// Info implements cmd.Command.
+func (c *parseCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "parse",
+		Args:    "<base>",
+		Purpose: "Show the structured form of a base.",
+		Doc:     parseDoc,
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *parseCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "yaml", formatters(formatParseTabular))
+}
+
+// Init implements cmd.Command.
+func (c *parseCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no base specified")
+	}
+	c.input, args = args[0], args[1:]
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements cmd.Command.
+func (c *parseCommand) Run(ctx *cmd.Context) error {
+	base, alias, err := parseInput(c.input)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	info := baseInfo{
+		OS:      base.Name,
+		Channel: newChannelInfo(base.Channel),
+		Base:    fullOf(base),
+		Version: versionOf(base),
+	}
+	if series, ok := seriesOf(base); ok {
+		info.Series = series
+	}
+	if alias != nil {
+		info.Alias = alias.Alias
+		info.Deprecated = alias.Deprecated
+	}
+	if eol, ok := base.EndOfLife(); ok {
+		info.EndOfLife = eol.Format("2006-01-02")
+	}
+	return c.out.Write(ctx, info)
+}
+
+func formatParseTabular(w io.Writer, value interface{}) error {
+	info, ok := value.(baseInfo)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", info, value)
+	}
+	tw := newTabWriter(w)
+	row := func(name, value string) {
+		if value != "" {
+			fmt.Fprintf(tw, "%s\t%s\n", name, value)
+		}
+	}
+	row("OS", info.OS)
+	row("Track", info.Channel.Track)
+	row("Risk", info.Channel.Risk)
+	row("Branch", info.Channel.Branch)
+	row("Base", info.Base)
+	row("Version", info.Version)
+	row("Series", info.Series)
+	if info.Alias != "" {
+		alias := info.Alias
+		if info.Deprecated {
+			alias += " (deprecated)"
+		}
+		row("Alias", alias)
+	}
+	row("End of life", info.EndOfLife)
+	return tw.Flush()
+}
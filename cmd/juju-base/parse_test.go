@@ -0,0 +1,81 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type parseSuite struct{}
+
+var _ = gc.Suite(&parseSuite{})
+
+func (s *parseSuite) TestInit(c *gc.C) {
+	cmdtesting.TestInit(c, &parseCommand{}, nil, "no base specified")
+	cmdtesting.TestInit(c, &parseCommand{}, []string{"focal", "bionic"}, `unrecognized args: \["bionic"\]`)
+}
+
+func (s *parseSuite) TestParseYAML(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, &parseCommand{}, "focal")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+os: ubuntu
+channel:
+  track: "20.04"
+  risk: stable
+base: ubuntu/20.04/stable
+version: ubuntu@20.04
+series: focal
+end-of-life: "2025-05-29"
+`[1:])
+}
+
+func (s *parseSuite) TestParseJSON(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, &parseCommand{}, "ubuntu@20.04", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `{"os":"ubuntu","channel":{"track":"20.04","risk":"stable"},"base":"ubuntu/20.04/stable","version":"ubuntu@20.04","series":"focal","end-of-life":"2025-05-29"}`+"\n")
+}
+
+func (s *parseSuite) TestParseTabular(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, &parseCommand{}, "opensuseleap", "--format", "tabular")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+OS           opensuse
+Track        opensuse42
+Risk         stable
+Base         opensuse/opensuse42/stable
+Version      opensuse@opensuse42
+Series       opensuse42
+Alias        opensuseleap (deprecated)
+End of life  2019-07-01
+
+`[1:])
+}
+
+func (s *parseSuite) TestParseBranch(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, &parseCommand{}, "ubuntu/22.04/edge/fix")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+os: ubuntu
+channel:
+  track: "22.04"
+  risk: edge
+  branch: fix
+base: ubuntu/22.04/edge/fix
+version: ubuntu@22.04
+`[1:])
+}
+
+func (s *parseSuite) TestParseInvalid(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, &parseCommand{}, "foo")
+	c.Check(err, gc.ErrorMatches, `series "foo" not valid`)
+
+	_, err = cmdtesting.RunCommand(c, &parseCommand{}, "ubuntu@")
+	c.Check(err, gc.ErrorMatches, `base "ubuntu@" not valid`)
+
+	_, err = cmdtesting.RunCommand(c, &parseCommand{}, "ubuntu@20.04@x")
+	c.Check(err, gc.ErrorMatches, `base "ubuntu@20.04@x" not valid`)
+}
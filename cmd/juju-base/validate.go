@@ -0,0 +1,163 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/systems"
+)
+
+const validateDoc = `
+Validates one or more bases and reports every issue found, including end of
+life releases, unstable risks and deprecated series names.
+
+The exit code is:
+    0  every base is valid;
+    1  at least one base is invalid;
+    2  the command was used incorrectly;
+    3  --strict was given and at least one base has warnings.
+
+Examples:
+    juju-base validate focal ubuntu@22.04
+    juju-base validate --strict opensuseleap
+`
+
+// Exit codes of the validate command, besides 0 on success and 2 on usage
+// errors.
+const (
+	exitInvalid  = 1
+	exitWarnings = 3
+)
+
+type validateCommand struct {
+	cmd.CommandBase
+	out    cmd.Output
+	clock  clock.Clock
+	strict bool
+	inputs []string
+}
+
+// validation is the structured output of a validated base.
+type validation struct {
+	Input  string      `yaml:"input" json:"input"`
+	Valid  bool        `yaml:"valid" json:"valid"`
+	Issues []issueInfo `yaml:"issues,omitempty" json:"issues,omitempty"`
+}
+
+type issueInfo struct {
+	Code     string `yaml:"code" json:"code"`
+	Severity string `yaml:"severity" json:"severity"`
+	Message  string `yaml:"message" json:"message"`
+}
+
+// Info implements cmd.Command.
+func (c *validateCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "validate",
+		Args:    "<base> ...",
+		Purpose: "Validate bases.",
+		Doc:     validateDoc,
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *validateCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "tabular", formatters(formatValidateTabular))
+	f.BoolVar(&c.strict, "strict", false, "Fail on warnings")
+}
+
+// Init implements cmd.Command.
+func (c *validateCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no base specified")
+	}
+	c.inputs = args
+	return nil
+}
+
+// Run implements cmd.Command.
+func (c *validateCommand) Run(ctx *cmd.Context) error {
+	now := c.clock.Now()
+	var (
+		results  []validation
+		invalid  bool
+		warnings bool
+	)
+	for _, input := range c.inputs {
+		issues := c.lint(input, now)
+		result := validation{Input: input, Valid: !issues.HasErrors()}
+		for _, issue := range issues {
+			result.Issues = append(result.Issues, issueInfo{
+				Code:     string(issue.Code),
+				Severity: string(issue.Severity),
+				Message:  issue.Message,
+			})
+		}
+		invalid = invalid || !result.Valid
+		warnings = warnings || len(issues.Filter(systems.SeverityWarning)) > 0
+		results = append(results, result)
+	}
+	if err := c.out.Write(ctx, results); err != nil {
+		return errors.Trace(err)
+	}
+	switch {
+	case invalid:
+		return cmd.NewRcPassthroughError(exitInvalid)
+	case c.strict && warnings:
+		return cmd.NewRcPassthroughError(exitWarnings)
+	}
+	return nil
+}
+
+// lint returns the issues of the base input. The os@version form is linted
+// as the equivalent os/track form.
+func (c *validateCommand) lint(input string, now time.Time) systems.Issues {
+	series, err := inputSeries(input)
+	if err != nil {
+		return systems.Issues{{
+			Code:     systems.IssueInvalidChannel,
+			Severity: systems.SeverityError,
+			Message:  err.Error(),
+		}}
+	}
+	return systems.LintSeries(series, now)
+}
+
+func formatValidateTabular(w io.Writer, value interface{}) error {
+	results, ok := value.([]validation)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", results, value)
+	}
+	tw := newTabWriter(w)
+	fmt.Fprintln(tw, "Base\tStatus\tIssues")
+	for _, r := range results {
+		status := "valid"
+		if !r.Valid {
+			status = "invalid"
+		}
+		var messages []string
+		for _, issue := range r.Issues {
+			messages = append(messages, fmt.Sprintf("%s: %s", issue.Severity, issue.Message))
+		}
+		if len(messages) == 0 {
+			messages = []string{"-"}
+		}
+		for i, message := range messages {
+			if i == 0 {
+				fmt.Fprintf(tw, "%s\t%s\t%s\n", r.Input, status, message)
+				continue
+			}
+			fmt.Fprintf(tw, "\t\t%s\n", message)
+		}
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,88 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	"github.com/juju/clock/testclock"
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type validateSuite struct{}
+
+var _ = gc.Suite(&validateSuite{})
+
+func newValidateCommand() *validateCommand {
+	return &validateCommand{clock: testclock.NewClock(testNow)}
+}
+
+func (s *validateSuite) TestInit(c *gc.C) {
+	cmdtesting.TestInit(c, newValidateCommand(), nil, "no base specified")
+}
+
+func (s *validateSuite) TestValid(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, newValidateCommand(), "focal", "ubuntu@20.04")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+Base          Status  Issues
+focal         valid   -
+ubuntu@20.04  valid   -
+
+`[1:])
+}
+
+func (s *validateSuite) TestInvalid(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, newValidateCommand(), "focal", "foo/1.0", "ubuntu@20.04@x", "--format", "yaml")
+	c.Assert(err, gc.DeepEquals, cmd.NewRcPassthroughError(exitInvalid))
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+- input: focal
+  valid: true
+- input: foo/1.0
+  valid: false
+  issues:
+  - code: unknown-os
+    severity: error
+    message: os "foo" not valid
+- input: ubuntu@20.04@x
+  valid: false
+  issues:
+  - code: invalid-channel
+    severity: error
+    message: base "ubuntu@20.04@x" not valid
+`[1:])
+}
+
+func (s *validateSuite) TestWarnings(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, newValidateCommand(), "xenial")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+Base    Status  Issues
+xenial  valid   warning: xenial reached end of life on 2021-04-30
+
+`[1:])
+
+	_, err = cmdtesting.RunCommand(c, newValidateCommand(), "--strict", "xenial")
+	c.Assert(err, gc.DeepEquals, cmd.NewRcPassthroughError(exitWarnings))
+}
+
+func (s *validateSuite) TestExitCodes(c *gc.C) {
+	tests := []struct {
+		args []string
+		code int
+	}{
+		{[]string{"validate", "focal"}, 0},
+		{[]string{"validate", "foo"}, exitInvalid},
+		{[]string{"validate", "--strict", "opensuseleap"}, exitWarnings},
+		{[]string{"validate"}, 2},
+	}
+	for i, test := range tests {
+		c.Logf("test %d: %v", i, test.args)
+		base := cmd.NewSuperCommand(cmd.SuperCommandParams{Name: "juju-base"})
+		base.Register(newValidateCommand())
+		ctx := cmdtesting.Context(c)
+		c.Check(cmd.Main(base, ctx, test.args), gc.Equals, test.code)
+	}
+}
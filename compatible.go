@@ -0,0 +1,137 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package systems
+
+import (
+	"fmt"
+
+	"github.com/juju/collections/set"
+
+	"github.com/juju/systems/channel"
+)
+
+// linuxOS is the set of OS names that belong to the Linux family, any of
+// which can host a charm declaring a GenericLinux base.
+var linuxOS = set.NewStrings(Ubuntu, CentOS, OpenSUSE, GenericLinux)
+
+// CompatibilityPolicy controls how strictly a declared base is matched
+// against a machine base.
+type CompatibilityPolicy int
+
+const (
+	// StrictPolicy requires the machine channel to be at least as stable
+	// as the declared channel.
+	StrictPolicy CompatibilityPolicy = iota
+
+	// PermissivePolicy also accepts a machine channel that is less stable
+	// than the declared channel, as long as the track matches.
+	PermissivePolicy
+)
+
+// Compatibility describes how a machine base satisfies a declared base.
+type Compatibility string
+
+// Compatibility results, from the strongest to the weakest.
+const (
+	// CompatibleExact means both bases are identical once normalized.
+	CompatibleExact Compatibility = "exact"
+	// CompatibleByFamily means the declared base is GenericLinux and the
+	// machine runs a Linux OS.
+	CompatibleByFamily Compatibility = "family"
+	// CompatibleByRisk means the OS and track match, but the machine is
+	// on a different risk or branch that is accepted by the policy.
+	CompatibleByRisk Compatibility = "risk"
+	// Incompatible means the machine base cannot host the declared base.
+	Incompatible Compatibility = "incompatible"
+)
+
+// CompatibilityResult holds the outcome of Base.CompatibleWith along with
+// a human readable reason.
+type CompatibilityResult struct {
+	Compatibility Compatibility
+	Reason        string
+}
+
+// Compatible returns true when the machine base can host the declared base.
+func (r CompatibilityResult) Compatible() bool {
+	return r.Compatibility != Incompatible && r.Compatibility != ""
+}
+
+// String returns the compatibility and reason, e.g. "risk: machine ...".
+func (r CompatibilityResult) String() string {
+	if r.Reason == "" {
+		return string(r.Compatibility)
+	}
+	return string(r.Compatibility) + ": " + r.Reason
+}
+
+// CompatibleWith reports whether the declared base s can be hosted by a
+// machine running the machine base, taking OS family, track and risk into
+// account. A branch is treated as closing back to its risk, so a machine on
+// "20.04/stable/foo" satisfies "20.04/stable" by risk.
+func (s Base) CompatibleWith(machine Base, policy CompatibilityPolicy) CompatibilityResult {
+	declaredCh := s.Channel.Clean()
+	machineCh := machine.Channel.Clean()
+
+	if s.Name == machine.Name && declaredCh == machineCh {
+		return CompatibilityResult{Compatibility: CompatibleExact}
+	}
+
+	if s.Name != machine.Name {
+		if s.Name == GenericLinux && linuxOS.Contains(machine.Name) {
+			return CompatibilityResult{
+				Compatibility: CompatibleByFamily,
+				Reason:        fmt.Sprintf("%s is a linux os", machine.Name),
+			}
+		}
+		return incompatible("os %q does not match %q", machine.Name, s.Name)
+	}
+
+	// GenericLinux does not carry a meaningful track, any channel will do.
+	if s.Name == GenericLinux {
+		return CompatibilityResult{
+			Compatibility: CompatibleByFamily,
+			Reason:        fmt.Sprintf("%s is a linux os", machine.Name),
+		}
+	}
+
+	match := declaredCh.Match(&machineCh)
+	if !match.Track {
+		return incompatible("track %q does not match %q", trackOf(machineCh), trackOf(declaredCh))
+	}
+	if !match.Risk && policy != PermissivePolicy {
+		return incompatible("risk %q is less stable than %q", machineCh.Risk, declaredCh.Risk)
+	}
+
+	reason := fmt.Sprintf("risk %q satisfies %q", machineCh.Risk, declaredCh.Risk)
+	if machineCh.Branch != declaredCh.Branch {
+		reason = fmt.Sprintf("branch %q closes to %q", machineCh.Branch, machineCh.Risk)
+		if machineCh.Branch == "" {
+			reason = fmt.Sprintf("branch %q closes to %q", declaredCh.Branch, declaredCh.Risk)
+		}
+	}
+	if !match.Risk {
+		reason = fmt.Sprintf("risk %q is permitted for %q", machineCh.Risk, declaredCh.Risk)
+	}
+	return CompatibilityResult{
+		Compatibility: CompatibleByRisk,
+		Reason:        reason,
+	}
+}
+
+// trackOf returns the track of a cleaned channel, restoring the implicit
+// "latest" track for display.
+func trackOf(c channel.Channel) string {
+	if c.Track == "" {
+		return "latest"
+	}
+	return c.Track
+}
+
+func incompatible(format string, args ...interface{}) CompatibilityResult {
+	return CompatibilityResult{
+		Compatibility: Incompatible,
+		Reason:        fmt.Sprintf(format, args...),
+	}
+}
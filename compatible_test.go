@@ -0,0 +1,60 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package systems_test
+
+import (
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/systems"
+	"github.com/juju/systems/channel"
+)
+
+type compatibleSuite struct{}
+
+var _ = gc.Suite(&compatibleSuite{})
+
+func mustBase(name, ch string) systems.Base {
+	return systems.Base{Name: name, Channel: channel.MustParse(ch)}
+}
+
+func (s *compatibleSuite) TestCompatibleWith(c *gc.C) {
+	tests := []struct {
+		declared   systems.Base
+		machine    systems.Base
+		policy     systems.CompatibilityPolicy
+		compatible systems.Compatibility
+		reason     string
+	}{
+		{mustBase(systems.Ubuntu, "20.04/stable"), mustBase(systems.Ubuntu, "20.04"), systems.StrictPolicy, systems.CompatibleExact, ""},
+		{mustBase(systems.Ubuntu, "20.04/stable"), mustBase(systems.Ubuntu, "20.04/stable"), systems.PermissivePolicy, systems.CompatibleExact, ""},
+		{mustBase(systems.Ubuntu, "20.04/edge"), mustBase(systems.Ubuntu, "20.04/stable"), systems.StrictPolicy, systems.CompatibleByRisk, `risk "stable" satisfies "edge"`},
+		{mustBase(systems.Ubuntu, "22.04/stable"), mustBase(systems.Ubuntu, "22.04/candidate"), systems.StrictPolicy, systems.Incompatible, `risk "candidate" is less stable than "stable"`},
+		{mustBase(systems.Ubuntu, "22.04/stable"), mustBase(systems.Ubuntu, "22.04/candidate"), systems.PermissivePolicy, systems.CompatibleByRisk, `risk "candidate" is permitted for "stable"`},
+		{mustBase(systems.Ubuntu, "20.04/stable"), mustBase(systems.Ubuntu, "20.04/stable/foo"), systems.StrictPolicy, systems.CompatibleByRisk, `branch "foo" closes to "stable"`},
+		{mustBase(systems.Ubuntu, "20.04/stable/foo"), mustBase(systems.Ubuntu, "20.04/stable"), systems.StrictPolicy, systems.CompatibleByRisk, `branch "foo" closes to "stable"`},
+		{mustBase(systems.Ubuntu, "20.04/stable"), mustBase(systems.Ubuntu, "18.04/stable"), systems.PermissivePolicy, systems.Incompatible, `track "18.04" does not match "20.04"`},
+		{mustBase(systems.Ubuntu, "20.04/stable"), mustBase(systems.Ubuntu, "stable"), systems.PermissivePolicy, systems.Incompatible, `track "latest" does not match "20.04"`},
+		{mustBase(systems.Ubuntu, "20.04/stable"), mustBase(systems.CentOS, "centos7/stable"), systems.PermissivePolicy, systems.Incompatible, `os "centos" does not match "ubuntu"`},
+		{mustBase(systems.GenericLinux, "latest/stable"), mustBase(systems.Ubuntu, "20.04/stable"), systems.StrictPolicy, systems.CompatibleByFamily, "ubuntu is a linux os"},
+		{mustBase(systems.GenericLinux, "latest/stable"), mustBase(systems.CentOS, "centos8/stable"), systems.StrictPolicy, systems.CompatibleByFamily, "centos is a linux os"},
+		{mustBase(systems.GenericLinux, "latest/stable"), mustBase(systems.GenericLinux, "latest/edge"), systems.StrictPolicy, systems.CompatibleByFamily, "genericlinux is a linux os"},
+		{mustBase(systems.GenericLinux, "latest/stable"), mustBase(systems.Windows, "win10/stable"), systems.PermissivePolicy, systems.Incompatible, `os "windows" does not match "genericlinux"`},
+		{mustBase(systems.Ubuntu, "20.04/stable"), mustBase(systems.GenericLinux, "latest/stable"), systems.PermissivePolicy, systems.Incompatible, `os "genericlinux" does not match "ubuntu"`},
+	}
+	for i, t := range tests {
+		comment := gc.Commentf("test %d: %v on %v", i, t.declared, t.machine)
+		res := t.declared.CompatibleWith(t.machine, t.policy)
+		c.Check(res.Compatibility, gc.Equals, t.compatible, comment)
+		c.Check(res.Reason, gc.Equals, t.reason, comment)
+		c.Check(res.Compatible(), gc.Equals, t.compatible != systems.Incompatible, comment)
+	}
+}
+
+func (s *compatibleSuite) TestCompatibilityResultString(c *gc.C) {
+	c.Check(systems.CompatibilityResult{Compatibility: systems.CompatibleExact}.String(), gc.Equals, "exact")
+	c.Check(systems.CompatibilityResult{
+		Compatibility: systems.Incompatible,
+		Reason:        "no",
+	}.String(), gc.Equals, "incompatible: no")
+}
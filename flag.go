@@ -0,0 +1,58 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package systems
+
+import (
+	"flag"
+
+	"github.com/juju/gnuflag"
+)
+
+var (
+	_ flag.Getter    = (*BaseFlag)(nil)
+	_ gnuflag.Getter = (*BaseFlag)(nil)
+)
+
+// BaseFlag is a command line flag holding a base, for use with both the
+// standard library flag package and gnuflag, e.g. for --base or --series.
+// It accepts the syntax of ParseBaseFromSeries, including legacy series.
+type BaseFlag struct {
+	// Base holds the parsed base, or the default base when the flag was not
+	// set.
+	Base Base
+	// IsSet is true when the flag was set explicitly on the command line.
+	IsSet bool
+}
+
+// NewBaseFlag returns a flag holding the given default base.
+func NewBaseFlag(defaultBase Base) *BaseFlag {
+	return &BaseFlag{Base: defaultBase}
+}
+
+// Set implements flag.Value and gnuflag.Value.
+func (f *BaseFlag) Set(s string) error {
+	base, err := ParseBaseFromSeries(s)
+	if err != nil {
+		return err
+	}
+	f.Base = base
+	f.IsSet = true
+	return nil
+}
+
+// String implements flag.Value and gnuflag.Value.
+func (f *BaseFlag) String() string {
+	if f == nil || f.Base == (Base{}) {
+		return ""
+	}
+	return f.Base.String()
+}
+
+// Get implements flag.Getter and gnuflag.Getter.
+func (f *BaseFlag) Get() interface{} {
+	if f == nil {
+		return Base{}
+	}
+	return f.Base
+}
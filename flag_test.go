@@ -0,0 +1,68 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package systems_test
+
+import (
+	"flag"
+	"io/ioutil"
+
+	"github.com/juju/gnuflag"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/systems"
+	"github.com/juju/systems/channel"
+)
+
+type flagSuite struct{}
+
+var _ = gc.Suite(&flagSuite{})
+
+func (s *flagSuite) TestStdlibFlag(c *gc.C) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	f := systems.NewBaseFlag(mustBase("ubuntu", "20.04/stable"))
+	fs.Var(f, "base", "the base")
+
+	c.Assert(fs.Parse(nil), jc.ErrorIsNil)
+	c.Check(f.IsSet, jc.IsFalse)
+	c.Check(f.String(), gc.Equals, "focal")
+
+	c.Assert(fs.Parse([]string{"-base", "ubuntu/22.04"}), jc.ErrorIsNil)
+	c.Check(f.IsSet, jc.IsTrue)
+	c.Check(f.Base, jc.DeepEquals, mustBase("ubuntu", "22.04/stable"))
+	c.Check(fs.Lookup("base").Value.(flag.Getter).Get(), jc.DeepEquals, f.Base)
+}
+
+func (s *flagSuite) TestGnuflag(c *gc.C) {
+	fs := gnuflag.NewFlagSet("test", gnuflag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	var f systems.BaseFlag
+	fs.Var(&f, "series", "the series")
+
+	c.Assert(fs.Parse(true, []string{"--series", "bionic"}), jc.ErrorIsNil)
+	c.Check(f.IsSet, jc.IsTrue)
+	c.Check(f.Base, jc.DeepEquals, mustBase("ubuntu", "18.04/stable"))
+	c.Check(f.String(), gc.Equals, "bionic")
+}
+
+func (s *flagSuite) TestInvalid(c *gc.C) {
+	fs := gnuflag.NewFlagSet("test", gnuflag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	f := systems.NewBaseFlag(systems.Base{})
+	fs.Var(f, "base", "the base")
+
+	err := fs.Parse(true, []string{"--base", "foo"})
+	c.Check(err, gc.ErrorMatches, `invalid value "foo" for flag --base: series "foo" not valid`)
+	c.Check(f.IsSet, jc.IsFalse)
+	c.Check(f.String(), gc.Equals, "")
+}
+
+func (s *flagSuite) TestZeroValue(c *gc.C) {
+	var f *systems.BaseFlag
+	c.Check(f.String(), gc.Equals, "")
+	c.Check(f.Get(), jc.DeepEquals, systems.Base{})
+	f = &systems.BaseFlag{Base: systems.Base{Name: "centos", Channel: channel.MustParse("8")}}
+	c.Check(f.String(), gc.Equals, "centos/8/stable")
+}
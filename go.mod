@@ -3,8 +3,13 @@ module github.com/juju/systems
 go 1.15
 
 require (
+	github.com/juju/clock v0.0.0-20190205081909-9c5c9712527c
+	github.com/juju/cmd v0.0.0-20171107070456-e74f39857ca0
 	github.com/juju/collections v0.0.0-20200605021417-0d0ec82b7271
 	github.com/juju/errors v0.0.0-20200330140219-3fe23663418f
+	github.com/juju/gnuflag v1.0.0
+	github.com/juju/schema v1.0.1
 	github.com/juju/testing v0.0.0-20200923013621-75df6121fbb0
 	gopkg.in/check.v1 v1.0.0-20200902074654-038fdea0a05b
+	gopkg.in/mgo.v2 v2.0.0-20190816093944-a6b53ec6cb22
 )
@@ -0,0 +1,90 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package systems
+
+import (
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/juju/systems/channel"
+)
+
+// JSONSchema returns a JSON Schema document validating a base in either its
+// string or its object form. The known OS names, series and risks are
+// generated from the builtin registry. The document also holds the channel
+// definitions of channel.JSONSchemaDefinitions, along with:
+//  - "os": the known OS names;
+//  - "series": the known legacy series, including aliases;
+//  - "baseString": the string form accepted by ParseBaseFromSeries;
+//  - "baseObject": the JSON encoding of Base, whose channel is always an
+//    object;
+//  - "base": either form.
+func JSONSchema() ([]byte, error) {
+	defs := channel.JSONSchemaDefinitions()
+	for name, def := range baseSchemaDefinitions() {
+		defs[name] = def
+	}
+	return json.MarshalIndent(map[string]interface{}{
+		"$schema": channel.JSONSchemaDraft,
+		"title":   "Base",
+		"$ref":    "#/$defs/base",
+		"$defs":   defs,
+	}, "", "  ")
+}
+
+func baseSchemaDefinitions() map[string]interface{} {
+	osNames := validOS.SortedValues()
+	quoted := make([]string, len(osNames))
+	for i, name := range osNames {
+		quoted[i] = regexp.QuoteMeta(name)
+	}
+	series := make([]string, 0, len(seriesToBases)+len(seriesAliases))
+	for s := range seriesToBases {
+		series = append(series, s)
+	}
+	for s := range seriesAliases {
+		series = append(series, s)
+	}
+	sort.Strings(series)
+
+	return map[string]interface{}{
+		"os": map[string]interface{}{
+			"type": "string",
+			"enum": osNames,
+		},
+		"series": map[string]interface{}{
+			"type": "string",
+			"enum": series,
+		},
+		"baseString": map[string]interface{}{
+			"anyOf": []interface{}{
+				map[string]interface{}{"$ref": "#/$defs/series"},
+				map[string]interface{}{
+					"type":    "string",
+					"pattern": "^(?:" + strings.Join(quoted, "|") + ")/[^/]+(?:/[^/]+){0,2}$",
+				},
+			},
+			"examples": []string{
+				"focal", "ubuntu/20.04", "centos/8/stable",
+			},
+		},
+		"baseObject": map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"name":    map[string]interface{}{"$ref": "#/$defs/os"},
+				"channel": map[string]interface{}{"$ref": "#/$defs/channelObject"},
+			},
+			"required":             []string{"name", "channel"},
+			"additionalProperties": false,
+		},
+		"base": map[string]interface{}{
+			"oneOf": []interface{}{
+				map[string]interface{}{"$ref": "#/$defs/baseString"},
+				map[string]interface{}{"$ref": "#/$defs/baseObject"},
+			},
+		},
+	}
+}
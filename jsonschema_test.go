@@ -0,0 +1,190 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package systems_test
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/systems"
+	"github.com/juju/systems/channel"
+)
+
+var updateGolden = flag.Bool("update", false, "update the golden files in testdata")
+
+type jsonSchemaSuite struct{}
+
+var _ = gc.Suite(&jsonSchemaSuite{})
+
+func (s *jsonSchemaSuite) TestGolden(c *gc.C) {
+	doc, err := systems.JSONSchema()
+	c.Assert(err, jc.ErrorIsNil)
+	doc = append(doc, '\n')
+
+	golden := filepath.Join("testdata", "base.schema.json")
+	if *updateGolden {
+		c.Assert(ioutil.WriteFile(golden, doc, 0644), jc.ErrorIsNil)
+	}
+	expected, err := ioutil.ReadFile(golden)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(doc), gc.Equals, string(expected), gc.Commentf("run go test -update to refresh %s", golden))
+}
+
+func (s *jsonSchemaSuite) defs(c *gc.C) map[string]interface{} {
+	doc, err := systems.JSONSchema()
+	c.Assert(err, jc.ErrorIsNil)
+	var schema map[string]interface{}
+	c.Assert(json.Unmarshal(doc, &schema), jc.ErrorIsNil)
+	return schema["$defs"].(map[string]interface{})
+}
+
+func (s *jsonSchemaSuite) TestSeriesEnumParses(c *gc.C) {
+	series := s.defs(c)["series"].(map[string]interface{})["enum"].([]interface{})
+	c.Assert(series, gc.Not(gc.HasLen), 0)
+	for _, name := range series {
+		_, err := systems.ParseBaseFromSeries(name.(string))
+		c.Check(err, jc.ErrorIsNil, gc.Commentf("series %q", name))
+	}
+}
+
+func (s *jsonSchemaSuite) TestBaseStringPattern(c *gc.C) {
+	anyOf := s.defs(c)["baseString"].(map[string]interface{})["anyOf"].([]interface{})
+	pattern := regexp.MustCompile(anyOf[1].(map[string]interface{})["pattern"].(string))
+	for _, str := range []string{"ubuntu/20.04", "centos/8/stable", "ubuntu/20.04/edge/fix", "windows/win10"} {
+		c.Check(pattern.MatchString(str), jc.IsTrue, gc.Commentf("%q", str))
+		_, err := systems.ParseBaseFromSeries(str)
+		c.Check(err, jc.ErrorIsNil)
+	}
+	for _, str := range []string{"ubuntu", "foo/20.04", "ubuntu/"} {
+		c.Check(pattern.MatchString(str), jc.IsFalse, gc.Commentf("%q", str))
+		_, err := systems.ParseBaseFromSeries(str)
+		c.Check(err, gc.NotNil)
+	}
+}
+
+func (s *jsonSchemaSuite) TestMarshalledBaseMatchesSchema(c *gc.C) {
+	defs := s.defs(c)
+	trackOnly, err := channel.ParseVerbatim("20.04")
+	c.Assert(err, jc.ErrorIsNil)
+	bases := []systems.Base{
+		mustBase(systems.Ubuntu, "20.04/stable"),
+		mustBase(systems.Ubuntu, "22.04/edge/fix"),
+		mustBase(systems.CentOS, "centos7/beta"),
+		mustBase(systems.GenericLinux, "stable"),
+		{Name: systems.Ubuntu, Channel: trackOnly},
+	}
+	for _, base := range bases {
+		data, err := json.Marshal(base)
+		c.Assert(err, jc.ErrorIsNil)
+		var doc interface{}
+		c.Assert(json.Unmarshal(data, &doc), jc.ErrorIsNil)
+		c.Check(validateSchema(defs, "#/$defs/base", doc), jc.ErrorIsNil, gc.Commentf("%s", data))
+
+		var again systems.Base
+		c.Assert(json.Unmarshal(data, &again), jc.ErrorIsNil)
+		c.Check(again, jc.DeepEquals, base)
+	}
+
+	for _, doc := range []string{
+		`{"name":"ubuntu","channel":"20.04/stable"}`,
+		`{"name":"ubuntu","channel":{"track":"20.04","risk":"bogus"}}`,
+		`{"name":"ubuntu","channel":{"track":"20.04","risk":"stable","foo":"bar"}}`,
+		`{"name":"ubuntu"}`,
+	} {
+		var v interface{}
+		c.Assert(json.Unmarshal([]byte(doc), &v), jc.ErrorIsNil)
+		c.Check(validateSchema(defs, "#/$defs/base", v), gc.NotNil, gc.Commentf("%s", doc))
+	}
+}
+
+// validateSchema validates the value against the schema referenced by ref,
+// supporting only the keywords used by systems.JSONSchema.
+func validateSchema(defs map[string]interface{}, ref string, v interface{}) error {
+	schema, ok := defs[strings.TrimPrefix(ref, "#/$defs/")].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("unknown reference %q", ref)
+	}
+	return validateSchemaNode(defs, schema, v)
+}
+
+func validateSchemaNode(defs, schema map[string]interface{}, v interface{}) error {
+	if ref, ok := schema["$ref"].(string); ok {
+		return validateSchema(defs, ref, v)
+	}
+	if any, ok := schema["anyOf"].([]interface{}); ok {
+		if countValid(defs, any, v) == 0 {
+			return fmt.Errorf("%v matches no anyOf schema", v)
+		}
+	}
+	if one, ok := schema["oneOf"].([]interface{}); ok {
+		if n := countValid(defs, one, v); n != 1 {
+			return fmt.Errorf("%v matches %d oneOf schemas", v, n)
+		}
+	}
+	if constant, ok := schema["const"]; ok && v != constant {
+		return fmt.Errorf("%v is not %v", v, constant)
+	}
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		found := false
+		for _, e := range enum {
+			found = found || e == v
+		}
+		if !found {
+			return fmt.Errorf("%v not in enum", v)
+		}
+	}
+	switch schema["type"] {
+	case "string":
+		str, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("%v is not a string", v)
+		}
+		if pattern, ok := schema["pattern"].(string); ok && !regexp.MustCompile(pattern).MatchString(str) {
+			return fmt.Errorf("%q does not match %q", str, pattern)
+		}
+	case "object":
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%v is not an object", v)
+		}
+		props, _ := schema["properties"].(map[string]interface{})
+		for name, value := range obj {
+			prop, ok := props[name].(map[string]interface{})
+			if !ok {
+				if schema["additionalProperties"] == false {
+					return fmt.Errorf("unexpected property %q", name)
+				}
+				continue
+			}
+			if err := validateSchemaNode(defs, prop, value); err != nil {
+				return fmt.Errorf("property %q: %v", name, err)
+			}
+		}
+		required, _ := schema["required"].([]interface{})
+		for _, name := range required {
+			if _, ok := obj[name.(string)]; !ok {
+				return fmt.Errorf("missing property %q", name)
+			}
+		}
+	}
+	return nil
+}
+
+func countValid(defs map[string]interface{}, schemas []interface{}, v interface{}) int {
+	n := 0
+	for _, schema := range schemas {
+		if validateSchemaNode(defs, schema.(map[string]interface{}), v) == nil {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,169 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package systems
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/systems/channel"
+)
+
+// Severity of a lint issue.
+type Severity string
+
+// Known severities.
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// IssueCode is a stable identifier for a kind of lint issue, it can be used
+// to display or suppress specific findings.
+type IssueCode string
+
+// Known issue codes.
+const (
+	IssueMissingName      IssueCode = "missing-name"
+	IssueUnknownOS        IssueCode = "unknown-os"
+	IssueMissingChannel   IssueCode = "missing-channel"
+	IssueInvalidChannel   IssueCode = "invalid-channel"
+	IssueEndOfLife        IssueCode = "end-of-life"
+	IssueUnstableRisk     IssueCode = "unstable-risk"
+	IssueBranch           IssueCode = "branch"
+	IssueDeprecatedSeries IssueCode = "deprecated-series"
+)
+
+// Issue is a single finding reported by Lint.
+type Issue struct {
+	Code     IssueCode
+	Severity Severity
+	Message  string
+}
+
+// String returns the issue in the form "severity: message (code)".
+func (i Issue) String() string {
+	return fmt.Sprintf("%s: %s (%s)", i.Severity, i.Message, i.Code)
+}
+
+// Issues is a list of lint issues.
+type Issues []Issue
+
+// HasErrors returns true if any of the issues is an error.
+func (is Issues) HasErrors() bool {
+	for _, i := range is {
+		if i.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter returns the issues with the given severity.
+func (is Issues) Filter(severity Severity) Issues {
+	var res Issues
+	for _, i := range is {
+		if i.Severity == severity {
+			res = append(res, i)
+		}
+	}
+	return res
+}
+
+// Suppress returns the issues without the ones matching any of the codes.
+func (is Issues) Suppress(codes ...IssueCode) Issues {
+	var res Issues
+next:
+	for _, i := range is {
+		for _, code := range codes {
+			if i.Code == code {
+				continue next
+			}
+		}
+		res = append(res, i)
+	}
+	return res
+}
+
+// String returns all the issues, one per line.
+func (is Issues) String() string {
+	lines := make([]string, len(is))
+	for k, i := range is {
+		lines[k] = i.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Lint returns every issue found with the base. Unlike Validate it does not
+// stop at the first problem, and it also reports warnings. Now is used to
+// determine whether the base has reached its end of life.
+func (s Base) Lint(now time.Time) Issues {
+	var issues Issues
+	add := func(code IssueCode, severity Severity, format string, args ...interface{}) {
+		issues = append(issues, Issue{
+			Code:     code,
+			Severity: severity,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	if s.Name == "" {
+		add(IssueMissingName, SeverityError, "name must be specified")
+	} else if !validOS.Contains(s.Name) {
+		add(IssueUnknownOS, SeverityError, "os %q not valid", s.Name)
+	}
+	if s.Channel == channel.Empty {
+		add(IssueMissingChannel, SeverityError, "channel must be specified")
+		return issues
+	}
+
+	ch := s.Channel.Clean()
+	series, release := releaseSeries(s)
+	if eol, ok := s.EndOfLife(); ok && !now.Before(eol) {
+		add(IssueEndOfLife, SeverityWarning, "%s reached end of life on %s", series, eol.Format("2006-01-02"))
+	}
+	if release && ch.Risk != channel.Stable {
+		add(IssueUnstableRisk, SeverityWarning, "risk %q is not stable for production base %s", ch.Risk, series)
+	}
+	if ch.Branch != "" {
+		add(IssueBranch, SeverityWarning, "branch %q is not expected on an os image channel", ch.Branch)
+	}
+	return issues
+}
+
+// LintSeries parses a base from a series string as ParseBaseFromSeries does
+// and returns every issue found, including the use of a deprecated series
+// name. Parse failures are reported as errors.
+func LintSeries(s string, now time.Time) Issues {
+	var issues Issues
+	if base, err := ParseBaseFromSeries(s); err == nil {
+		issues = base.Lint(now)
+	} else {
+		// Lint the unparsed components, to report as much detail as
+		// possible.
+		segments := strings.SplitN(s, "/", 2)
+		base = Base{Name: segments[0]}
+		var channelErr error
+		if len(segments) == 2 {
+			base.Channel, channelErr = channel.Parse(segments[1])
+		}
+		issues = base.Lint(now)
+		if channelErr != nil {
+			issues = append(issues.Suppress(IssueMissingChannel), Issue{
+				Code:     IssueInvalidChannel,
+				Severity: SeverityError,
+				Message:  channelErr.Error(),
+			})
+		}
+	}
+	if alias, ok := seriesAliases[s]; ok && alias.Deprecated {
+		issues = append(issues, Issue{
+			Code:     IssueDeprecatedSeries,
+			Severity: SeverityWarning,
+			Message:  alias.String(),
+		})
+	}
+	return issues
+}
@@ -0,0 +1,108 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package systems_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/systems"
+	"github.com/juju/systems/channel"
+)
+
+type lintSuite struct{}
+
+var _ = gc.Suite(&lintSuite{})
+
+var lintNow = time.Date(2022, time.June, 1, 0, 0, 0, 0, time.UTC)
+
+func issueCodes(issues systems.Issues) []systems.IssueCode {
+	codes := []systems.IssueCode{}
+	for _, i := range issues {
+		codes = append(codes, i.Code)
+	}
+	return codes
+}
+
+func (s *lintSuite) TestLint(c *gc.C) {
+	tests := []struct {
+		base  systems.Base
+		codes []systems.IssueCode
+	}{
+		{systems.Base{}, []systems.IssueCode{systems.IssueMissingName, systems.IssueMissingChannel}},
+		{systems.Base{Name: "mythicalos"}, []systems.IssueCode{systems.IssueUnknownOS, systems.IssueMissingChannel}},
+		{mustBase(systems.Ubuntu, "20.04/stable"), []systems.IssueCode{}},
+		{mustBase(systems.Ubuntu, "22.04/edge"), []systems.IssueCode{}},
+		{mustBase(systems.Ubuntu, "20.04/candidate"), []systems.IssueCode{systems.IssueUnstableRisk}},
+		{mustBase(systems.Ubuntu, "16.04/stable"), []systems.IssueCode{systems.IssueEndOfLife}},
+		{mustBase(systems.Ubuntu, "20.04/stable/foo"), []systems.IssueCode{systems.IssueBranch}},
+		{mustBase(systems.Ubuntu, "18.04/edge/foo"), []systems.IssueCode{systems.IssueUnstableRisk, systems.IssueBranch}},
+		{mustBase(systems.CentOS, "centos8/stable"), []systems.IssueCode{systems.IssueEndOfLife}},
+	}
+	for i, t := range tests {
+		issues := t.base.Lint(lintNow)
+		c.Check(issueCodes(issues), jc.DeepEquals, t.codes, gc.Commentf("test %d: %v", i, t.base))
+	}
+}
+
+func (s *lintSuite) TestLintMessages(c *gc.C) {
+	issues := mustBase(systems.Ubuntu, "16.04/edge/foo").Lint(lintNow)
+	c.Check(issues.String(), gc.Equals, `
+warning: xenial reached end of life on 2021-04-30 (end-of-life)
+warning: risk "edge" is not stable for production base xenial (unstable-risk)
+warning: branch "foo" is not expected on an os image channel (branch)`[1:])
+	c.Check(issues.HasErrors(), jc.IsFalse)
+}
+
+func (s *lintSuite) TestLintSeries(c *gc.C) {
+	tests := []struct {
+		series string
+		codes  []systems.IssueCode
+	}{
+		{"focal", []systems.IssueCode{}},
+		{"xenial", []systems.IssueCode{systems.IssueEndOfLife}},
+		{"opensuseleap", []systems.IssueCode{systems.IssueEndOfLife, systems.IssueDeprecatedSeries}},
+		{"ubuntu", []systems.IssueCode{systems.IssueMissingChannel}},
+		{"ubuntu/20.04/bogus", []systems.IssueCode{systems.IssueInvalidChannel}},
+		{"mythicalos/1.0", []systems.IssueCode{systems.IssueUnknownOS}},
+		{"", []systems.IssueCode{systems.IssueMissingName, systems.IssueMissingChannel}},
+	}
+	for i, t := range tests {
+		issues := systems.LintSeries(t.series, lintNow)
+		c.Check(issueCodes(issues), jc.DeepEquals, t.codes, gc.Commentf("test %d: %q", i, t.series))
+	}
+}
+
+func (s *lintSuite) TestLintSeriesDeprecated(c *gc.C) {
+	issues := systems.LintSeries("opensuseleap", lintNow)
+	c.Check(issues.String(), gc.Equals, `
+warning: opensuse42 reached end of life on 2019-07-01 (end-of-life)
+warning: series "opensuseleap" is deprecated, use "opensuse42" instead (deprecated-series)`[1:])
+	c.Check(issues.HasErrors(), jc.IsFalse)
+
+	// The preferred series is not deprecated.
+	c.Check(issueCodes(systems.LintSeries("opensuse42", lintNow)), jc.DeepEquals, []systems.IssueCode{systems.IssueEndOfLife})
+}
+
+func (s *lintSuite) TestIssuesFilterAndSuppress(c *gc.C) {
+	issues := systems.Base{Name: systems.Ubuntu, Channel: channel.MustParse("16.04/edge")}.Lint(lintNow)
+	c.Assert(issues, gc.HasLen, 2)
+	c.Check(issues.Filter(systems.SeverityError), gc.HasLen, 0)
+	c.Check(issues.Filter(systems.SeverityWarning), gc.HasLen, 2)
+	c.Check(issueCodes(issues.Suppress(systems.IssueEndOfLife)), jc.DeepEquals, []systems.IssueCode{systems.IssueUnstableRisk})
+
+	issues = systems.LintSeries("ubuntu", lintNow)
+	c.Check(issues.HasErrors(), jc.IsTrue)
+}
+
+func (s *lintSuite) TestEndOfLife(c *gc.C) {
+	eol, ok := mustBase(systems.Ubuntu, "20.04/edge").EndOfLife()
+	c.Assert(ok, jc.IsTrue)
+	c.Check(eol, gc.Equals, time.Date(2025, time.May, 29, 0, 0, 0, 0, time.UTC))
+
+	_, ok = mustBase(systems.Ubuntu, "22.04/stable").EndOfLife()
+	c.Check(ok, jc.IsFalse)
+}
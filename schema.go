@@ -0,0 +1,91 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package systems
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+
+	"github.com/juju/systems/channel"
+)
+
+// BaseCheck is an additional check applied to a coerced base by the
+// BaseChecker.
+type BaseCheck func(Base) error
+
+// SupportedBase returns a check that the base is one of the supported bases.
+// When no base is supported, every base is rejected.
+func SupportedBase(supported ...Base) BaseCheck {
+	return func(b Base) error {
+		if len(supported) == 0 {
+			return errors.Errorf("base %q is not supported, no bases are supported", b)
+		}
+		names := make([]string, len(supported))
+		for i, s := range supported {
+			if s.Canonical() == b {
+				return nil
+			}
+			names[i] = s.String()
+		}
+		return errors.Errorf("base %q is not supported, expected one of %s", b, strings.Join(names, ", "))
+	}
+}
+
+// BaseChecker returns a schema.Checker that coerces a base string, with the
+// syntax of ParseBaseFromSeries including legacy series, or a map with "name"
+// and "channel" keys into a canonical Base. The channel of a map is checked
+// with channel.ChannelChecker. The checks, if any, are then applied in order.
+// Errors are prefixed with the path of the value being checked.
+func BaseChecker(checks ...BaseCheck) schema.Checker {
+	return baseC{checks: checks}
+}
+
+type baseC struct {
+	checks []BaseCheck
+}
+
+var baseFields = schema.FieldMap(schema.Fields{
+	"name":    schema.String(),
+	"channel": channel.ChannelChecker(),
+}, nil)
+
+// Coerce implements schema.Checker.
+func (c baseC) Coerce(v interface{}, path []string) (interface{}, error) {
+	var (
+		base Base
+		err  error
+	)
+	switch value := reflect.ValueOf(v); {
+	case v == nil:
+		return nil, errors.Errorf("%sexpected base string or map, got nothing", channel.SchemaPathPrefix(path))
+	case value.Kind() == reflect.String:
+		base, err = ParseBaseFromSeries(value.String())
+	case value.Kind() == reflect.Map:
+		var fields interface{}
+		if fields, err = baseFields.Coerce(v, path); err != nil {
+			return nil, err
+		}
+		m := fields.(map[string]interface{})
+		base = Base{
+			Name:    m["name"].(string),
+			Channel: m["channel"].(channel.Channel),
+		}
+		err = base.Validate()
+	default:
+		return nil, errors.Errorf("%sexpected base string or map, got %T(%#v)", channel.SchemaPathPrefix(path), v, v)
+	}
+	if err != nil {
+		return nil, errors.Errorf("%s%v", channel.SchemaPathPrefix(path), err)
+	}
+	base = base.Canonical()
+	for _, check := range c.checks {
+		if err := check(base); err != nil {
+			return nil, errors.Errorf("%s%v", channel.SchemaPathPrefix(path), err)
+		}
+	}
+	return base, nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package systems_test
+
+import (
+	"github.com/juju/schema"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/systems"
+)
+
+type schemaSuite struct{}
+
+var _ = gc.Suite(&schemaSuite{})
+
+func (s *schemaSuite) TestBaseChecker(c *gc.C) {
+	tests := []struct {
+		value interface{}
+		base  systems.Base
+	}{{
+		value: "focal",
+		base:  mustBase("ubuntu", "20.04/stable"),
+	}, {
+		value: "ubuntu/22.04",
+		base:  mustBase("ubuntu", "22.04/stable"),
+	}, {
+		value: map[string]interface{}{"name": "ubuntu", "channel": "20.04"},
+		base:  mustBase("ubuntu", "20.04/stable"),
+	}, {
+		value: map[interface{}]interface{}{
+			"name":    "centos",
+			"channel": map[interface{}]interface{}{"track": "8", "risk": "candidate"},
+		},
+		base: mustBase("centos", "8/candidate"),
+	}}
+	for i, test := range tests {
+		c.Logf("test %d: %v", i, test.value)
+		v, err := systems.BaseChecker().Coerce(test.value, nil)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(v, jc.DeepEquals, test.base)
+	}
+}
+
+func (s *schemaSuite) TestBaseCheckerErrors(c *gc.C) {
+	tests := []struct {
+		value interface{}
+		err   string
+	}{{
+		value: nil,
+		err:   `config.base: expected base string or map, got nothing`,
+	}, {
+		value: 42,
+		err:   `config.base: expected base string or map, got int\(42\)`,
+	}, {
+		value: "foo",
+		err:   `config.base: series "foo" not valid`,
+	}, {
+		value: map[string]interface{}{"name": "ubuntu"},
+		err:   `config.base.channel: expected channel string or map, got nothing`,
+	}, {
+		value: map[string]interface{}{"name": "ubuntu", "channel": "a/b/c/d"},
+		err:   `config.base.channel: channel name has too many components: a/b/c/d`,
+	}, {
+		value: map[string]interface{}{"name": "foo", "channel": "1.0"},
+		err:   `config.base: os "foo" not valid`,
+	}}
+	for i, test := range tests {
+		c.Logf("test %d: %v", i, test.value)
+		_, err := systems.BaseChecker().Coerce(test.value, []string{"config", ".base"})
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *schemaSuite) TestSupportedBase(c *gc.C) {
+	checker := systems.BaseChecker(systems.SupportedBase(
+		mustBase("ubuntu", "20.04/stable"),
+		mustBase("ubuntu", "16.04/stable"),
+	))
+	v, err := checker.Coerce("ubuntu/20.04/stable", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(v, jc.DeepEquals, mustBase("ubuntu", "20.04/stable"))
+
+	_, err = checker.Coerce("bionic", []string{"base"})
+	c.Check(err, gc.ErrorMatches, `base: base "bionic" is not supported, expected one of focal, xenial`)
+
+	checker = systems.BaseChecker(systems.SupportedBase())
+	_, err = checker.Coerce("focal", []string{"base"})
+	c.Check(err, gc.ErrorMatches, `base: base "focal" is not supported, no bases are supported`)
+}
+
+func (s *schemaSuite) TestFieldMap(c *gc.C) {
+	checker := schema.FieldMap(schema.Fields{
+		"bases": schema.List(systems.BaseChecker()),
+	}, nil)
+	v, err := checker.Coerce(map[string]interface{}{
+		"bases": []interface{}{"focal", "ubuntu/22.04"},
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(v.(map[string]interface{})["bases"], jc.DeepEquals, []interface{}{
+		mustBase("ubuntu", "20.04/stable"),
+		mustBase("ubuntu", "22.04/stable"),
+	})
+
+	_, err = checker.Coerce(map[string]interface{}{
+		"bases": []interface{}{"focal", "bar"},
+	}, nil)
+	c.Check(err, gc.ErrorMatches, `bases\[1\]: series "bar" not valid`)
+}
@@ -0,0 +1,111 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package systems
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+var (
+	_ driver.Valuer = Base{}
+	_ sql.Scanner   = (*Base)(nil)
+	_ driver.Valuer = NullBase{}
+	_ sql.Scanner   = (*NullBase)(nil)
+)
+
+// Value implements driver.Valuer. The base is stored in the string form of
+// its canonical base, e.g. "focal" or "centos/8/stable", so that equal bases
+// are stored identically.
+func (s Base) Value() (driver.Value, error) {
+	if err := s.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return s.String(), nil
+}
+
+// Scan implements sql.Scanner. It accepts the string form of a base,
+// including legacy series, as well as the legacy JSON object form, e.g.
+// {"name":"ubuntu","channel":{"track":"20.04","risk":"stable"}}. The
+// scanned base is canonical.
+func (s *Base) Scan(src interface{}) error {
+	var str string
+	switch v := src.(type) {
+	case string:
+		str = v
+	case []byte:
+		str = string(v)
+	case nil:
+		return errors.Errorf("cannot scan NULL into base")
+	default:
+		return errors.Errorf("cannot scan %T into base", src)
+	}
+	base, err := parseStoredBase(str)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	*s = base
+	return nil
+}
+
+// parseStoredBase parses a base string or a legacy JSON object, whose
+// channel is either a string or an object.
+func parseStoredBase(str string) (Base, error) {
+	if !strings.HasPrefix(strings.TrimSpace(str), "{") {
+		return ParseBaseFromSeries(str)
+	}
+	var legacy struct {
+		Name    string          `json:"name"`
+		Channel json.RawMessage `json:"channel"`
+	}
+	if err := json.Unmarshal([]byte(str), &legacy); err != nil {
+		return Base{}, errors.Annotate(err, "invalid legacy base")
+	}
+	base := Base{Name: legacy.Name}
+	if len(legacy.Channel) > 0 {
+		var chStr string
+		if json.Unmarshal(legacy.Channel, &chStr) == nil {
+			legacy.Channel = []byte(chStr)
+		}
+		if err := base.Channel.Scan([]byte(legacy.Channel)); err != nil {
+			return Base{}, errors.Annotate(err, "invalid legacy base")
+		}
+	}
+	if err := base.Validate(); err != nil {
+		return Base{}, errors.Annotate(err, "invalid legacy base")
+	}
+	return base.Canonical(), nil
+}
+
+// NullBase is a base that may be NULL, for use with optional columns.
+type NullBase struct {
+	Base Base
+	// Valid is true if Base is not NULL.
+	Valid bool
+}
+
+// Value implements driver.Valuer.
+func (n NullBase) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Base.Value()
+}
+
+// Scan implements sql.Scanner.
+func (n *NullBase) Scan(src interface{}) error {
+	if src == nil {
+		n.Base, n.Valid = Base{}, false
+		return nil
+	}
+	if err := n.Base.Scan(src); err != nil {
+		return errors.Trace(err)
+	}
+	n.Valid = true
+	return nil
+}
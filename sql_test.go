@@ -0,0 +1,118 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package systems_test
+
+import (
+	"database/sql/driver"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/systems"
+)
+
+type sqlSuite struct{}
+
+var _ = gc.Suite(&sqlSuite{})
+
+func (s *sqlSuite) TestValue(c *gc.C) {
+	v, err := mustBase("ubuntu", "20.04").Value()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(v, gc.Equals, "focal")
+
+	v, err = mustBase("centos", "8/candidate").Value()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(v, gc.Equals, "centos/8/candidate")
+
+	_, err = systems.Base{}.Value()
+	c.Check(err, gc.ErrorMatches, "name must be specified not valid")
+}
+
+func (s *sqlSuite) TestValueChannelForm(c *gc.C) {
+	// A stored base holds its channel in the same form as a stored channel.
+	base := mustBase("ubuntu", "latest/edge")
+	v, err := base.Value()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(v, gc.Equals, "ubuntu/edge")
+	ch, err := base.Channel.Value()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(v, gc.Equals, "ubuntu/"+ch.(string))
+}
+
+func (s *sqlSuite) TestScan(c *gc.C) {
+	tests := []struct {
+		src  interface{}
+		base systems.Base
+		err  string
+	}{{
+		src:  "focal",
+		base: mustBase("ubuntu", "20.04/stable"),
+	}, {
+		src:  []byte("ubuntu/20.04"),
+		base: mustBase("ubuntu", "20.04/stable"),
+	}, {
+		src:  `{"name":"ubuntu","channel":{"name":"20.04/stable","track":"20.04","risk":"stable"}}`,
+		base: mustBase("ubuntu", "20.04/stable"),
+	}, {
+		src:  []byte(`{"name":"centos","channel":"8"}`),
+		base: mustBase("centos", "8/stable"),
+	}, {
+		src: nil,
+		err: "cannot scan NULL into base",
+	}, {
+		src: 42,
+		err: "cannot scan int into base",
+	}, {
+		src: "foo",
+		err: `series "foo" not valid`,
+	}, {
+		src: `{"name":"ubuntu"`,
+		err: "invalid legacy base: unexpected end of JSON input",
+	}, {
+		src: `{"name":"foo","channel":"1.0"}`,
+		err: `invalid legacy base: os "foo" not valid`,
+	}}
+	for i, test := range tests {
+		c.Logf("test %d: %v", i, test.src)
+		var base systems.Base
+		err := base.Scan(test.src)
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			continue
+		}
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(base, jc.DeepEquals, test.base)
+	}
+}
+
+func (s *sqlSuite) TestRoundTrip(c *gc.C) {
+	for _, str := range []string{"bionic", "win2012r2", "centos/7/stable", "ubuntu/22.04/edge/fix"} {
+		base, err := systems.ParseBaseFromSeries(str)
+		c.Assert(err, jc.ErrorIsNil)
+		v, err := base.Value()
+		c.Assert(err, jc.ErrorIsNil)
+		var scanned systems.Base
+		c.Assert(scanned.Scan(v), jc.ErrorIsNil)
+		c.Check(scanned, jc.DeepEquals, base)
+	}
+}
+
+func (s *sqlSuite) TestNullBase(c *gc.C) {
+	var n systems.NullBase
+	v, err := n.Value()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(v, gc.IsNil)
+
+	c.Assert(n.Scan("focal"), jc.ErrorIsNil)
+	c.Check(n, jc.DeepEquals, systems.NullBase{Base: mustBase("ubuntu", "20.04/stable"), Valid: true})
+	v, err = n.Value()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(v, gc.Equals, driver.Value("focal"))
+
+	c.Assert(n.Scan(nil), jc.ErrorIsNil)
+	c.Check(n, jc.DeepEquals, systems.NullBase{})
+
+	c.Check(n.Scan("foo"), gc.ErrorMatches, `series "foo" not valid`)
+	c.Check(n.Valid, jc.IsFalse)
+}
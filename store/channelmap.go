@@ -0,0 +1,165 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+// Package store decodes the channel-map found in Charmhub and snap store
+// responses into the channel and systems types.
+package store
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/systems"
+	"github.com/juju/systems/channel"
+)
+
+// Entry is a single release in a store channel-map.
+type Entry struct {
+	Channel      channel.Channel
+	Base         systems.Base
+	Architecture string
+	Revision     int
+	Version      string
+	ReleasedAt   time.Time
+}
+
+// ChannelMap is a decoded store channel-map.
+type ChannelMap []Entry
+
+// Releases converts the channel-map into a channel.ChannelMap, which can be
+// used to resolve channels. The release base is the "os/track" form of the
+// entry base, e.g. "ubuntu/20.04", see ReleaseBase.
+func (m ChannelMap) Releases() channel.ChannelMap {
+	releases := make(channel.ChannelMap, len(m))
+	for i, e := range m {
+		releases[i] = channel.Release{
+			Channel:      e.Channel,
+			Revision:     e.Revision,
+			Architecture: e.Architecture,
+		}
+		if e.Base.Name != "" {
+			releases[i].Base = ReleaseBase(e.Base)
+		}
+	}
+	return releases
+}
+
+// ReleaseBase returns the "os/track" form of the base used by
+// channel.Release.Base and channel.ResolveOptions.Base, e.g. "ubuntu/20.04".
+func ReleaseBase(b systems.Base) string {
+	track := b.Channel.Clean().Track
+	if track == "" {
+		track = channel.DefaultTrack
+	}
+	return b.Name + "/" + track
+}
+
+type response struct {
+	ChannelMap []entry `json:"channel-map"`
+}
+
+type entry struct {
+	Channel  entryChannel    `json:"channel"`
+	Revision json.RawMessage `json:"revision"`
+	Version  string          `json:"version"`
+}
+
+type entryChannel struct {
+	Name         string     `json:"name"`
+	Track        string     `json:"track"`
+	Risk         string     `json:"risk"`
+	Branch       string     `json:"branch"`
+	Architecture string     `json:"architecture"`
+	Base         *entryBase `json:"base"`
+	ReleasedAt   time.Time  `json:"released-at"`
+}
+
+type entryBase struct {
+	Name         string `json:"name"`
+	Channel      string `json:"channel"`
+	Architecture string `json:"architecture"`
+}
+
+// Charmhub nests the revision number in an object, the snap store does not.
+type entryRevision struct {
+	Revision int    `json:"revision"`
+	Version  string `json:"version"`
+}
+
+// UnmarshalChannelMap decodes the "channel-map" of a Charmhub or snap store
+// info response.
+func UnmarshalChannelMap(data []byte) (ChannelMap, error) {
+	var resp response
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, errors.Annotate(err, "cannot decode channel-map")
+	}
+	m := make(ChannelMap, len(resp.ChannelMap))
+	for i, raw := range resp.ChannelMap {
+		e, err := raw.decode()
+		if err != nil {
+			return nil, errors.Annotatef(err, "channel-map entry %d", i)
+		}
+		m[i] = e
+	}
+	return m, nil
+}
+
+func (raw entry) decode() (Entry, error) {
+	ch, err := channel.Parse(raw.Channel.Name)
+	if err != nil {
+		return Entry{}, errors.Trace(err)
+	}
+	expected := channel.Channel{
+		Track:  raw.Channel.Track,
+		Risk:   channel.Risk(raw.Channel.Risk),
+		Branch: raw.Channel.Branch,
+	}.Clean()
+	if raw.Channel.Branch == "" {
+		// The store omits the branch, it is only found in the name.
+		expected.Branch = ch.Branch
+		expected = expected.Clean()
+	}
+	if ch != expected {
+		return Entry{}, errors.NotValidf("channel %q with track %q and risk %q",
+			raw.Channel.Name, raw.Channel.Track, raw.Channel.Risk)
+	}
+
+	e := Entry{
+		Channel:      ch,
+		Architecture: raw.Channel.Architecture,
+		Version:      raw.Version,
+		ReleasedAt:   raw.Channel.ReleasedAt,
+	}
+
+	if b := raw.Channel.Base; b != nil {
+		e.Base, err = systems.ParseBaseFromSeries(b.Name + "/" + b.Channel)
+		if err != nil {
+			return Entry{}, errors.Trace(err)
+		}
+		if b.Architecture != "" {
+			e.Architecture = b.Architecture
+		}
+	}
+
+	revision := strings.TrimSpace(string(raw.Revision))
+	switch {
+	case revision == "" || revision == "null":
+	case strings.HasPrefix(revision, "{"):
+		var rev entryRevision
+		if err := json.Unmarshal(raw.Revision, &rev); err != nil {
+			return Entry{}, errors.Annotate(err, "cannot decode revision")
+		}
+		e.Revision = rev.Revision
+		if rev.Version != "" {
+			e.Version = rev.Version
+		}
+	default:
+		if err := json.Unmarshal(raw.Revision, &e.Revision); err != nil {
+			return Entry{}, errors.Annotate(err, "cannot decode revision")
+		}
+	}
+	return e, nil
+}
@@ -0,0 +1,126 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+package store_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/systems"
+	"github.com/juju/systems/channel"
+	"github.com/juju/systems/store"
+)
+
+type channelMapSuite struct{}
+
+var _ = gc.Suite(&channelMapSuite{})
+
+func readFixture(c *gc.C, name string) []byte {
+	data, err := ioutil.ReadFile(filepath.Join("testdata", name))
+	c.Assert(err, jc.ErrorIsNil)
+	return data
+}
+
+func (s *channelMapSuite) TestUnmarshalCharmhub(c *gc.C) {
+	m, err := store.UnmarshalChannelMap(readFixture(c, "charmhub-info.json"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m, gc.HasLen, 3)
+
+	focal := systems.Base{Name: systems.Ubuntu, Channel: channel.MustParse("20.04/stable")}
+	c.Check(m[0], jc.DeepEquals, store.Entry{
+		Channel:      channel.MustParse("stable"),
+		Base:         focal,
+		Architecture: "amd64",
+		Revision:     18,
+		Version:      "18",
+		ReleasedAt:   time.Date(2021, time.April, 8, 9, 2, 49, 519375000, time.UTC),
+	})
+	c.Check(m[1].Base.String(), gc.Equals, "bionic")
+	c.Check(m[2].Channel, jc.DeepEquals, channel.Channel{
+		Name:   "2.0/edge/fix-123",
+		Track:  "2.0",
+		Risk:   channel.Edge,
+		Branch: "fix-123",
+	})
+	c.Check(m[2].Revision, gc.Equals, 22)
+}
+
+func (s *channelMapSuite) TestUnmarshalSnap(c *gc.C) {
+	m, err := store.UnmarshalChannelMap(readFixture(c, "snap-info.json"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m, gc.HasLen, 2)
+	c.Check(m[0], jc.DeepEquals, store.Entry{
+		Channel:      channel.MustParse("stable"),
+		Architecture: "amd64",
+		Revision:     1234,
+		Version:      "2.8.10",
+		ReleasedAt:   time.Date(2021, time.March, 29, 13, 43, 17, 428046000, time.UTC),
+	})
+	c.Check(m[1].Channel, jc.DeepEquals, channel.MustParse("2.9/candidate"))
+	c.Check(m[1].Architecture, gc.Equals, "arm64")
+	c.Check(m[1].Revision, gc.Equals, 1300)
+}
+
+func (s *channelMapSuite) TestReleases(c *gc.C) {
+	m, err := store.UnmarshalChannelMap(readFixture(c, "charmhub-info.json"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	res, err := m.Releases().Resolve(channel.MustParse("edge"), channel.ResolveOptions{
+		Architecture: "amd64",
+		Base:         "ubuntu/18.04",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res.Release, jc.DeepEquals, channel.Release{
+		Channel:      channel.MustParse("stable"),
+		Revision:     18,
+		Architecture: "amd64",
+		Base:         "ubuntu/18.04",
+	})
+
+	// The base is matched in its "os/track" form only.
+	_, err = m.Releases().Resolve(channel.MustParse("edge"), channel.ResolveOptions{
+		Architecture: "amd64",
+		Base:         "bionic",
+	})
+	c.Check(errors.Is(err, channel.ErrNoRelease), jc.IsTrue)
+}
+
+func (s *channelMapSuite) TestReleaseBase(c *gc.C) {
+	tests := []struct {
+		base     string
+		expected string
+	}{
+		{"focal", "ubuntu/20.04"},
+		{"ubuntu/22.04/edge", "ubuntu/22.04"},
+		{"centos7", "centos/centos7"},
+		{"genericlinux", "genericlinux/latest"},
+	}
+	for _, t := range tests {
+		base, err := systems.ParseBaseFromSeries(t.base)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(store.ReleaseBase(base), gc.Equals, t.expected, gc.Commentf("%q", t.base))
+	}
+}
+
+func (s *channelMapSuite) TestUnmarshalErrors(c *gc.C) {
+	tests := []struct {
+		data string
+		err  string
+	}{
+		{`[]`, "cannot decode channel-map: .*"},
+		{`{"channel-map": [{"channel": {"name": "1.0/bogus"}}]}`, "channel-map entry 0: invalid risk in channel name: 1.0/bogus"},
+		{`{"channel-map": [{"channel": {"name": "stable", "track": "2.0", "risk": "stable"}}]}`, `channel-map entry 0: channel "stable" with track "2.0" and risk "stable" not valid`},
+		{`{"channel-map": [{"channel": {"name": "stable", "base": {"name": "mythicalos", "channel": "1"}}}]}`, `channel-map entry 0: series "mythicalos/1" not valid`},
+		{`{"channel-map": [{"channel": {"name": "stable"}, "revision": "x"}]}`, "channel-map entry 0: cannot decode revision: .*"},
+	}
+	for _, t := range tests {
+		_, err := store.UnmarshalChannelMap([]byte(t.data))
+		c.Check(err, gc.ErrorMatches, t.err, gc.Commentf(t.data))
+	}
+}
@@ -34,8 +34,29 @@ func (s Base) Validate() error {
 	return nil
 }
 
+// Canonical returns the base with a normalized channel. Bases that describe
+// the same OS and channel have equal canonical forms, so canonical bases can
+// safely be compared or used as map keys.
+func (s Base) Canonical() Base {
+	if s.Channel == channel.Empty {
+		return Base{Name: s.Name}
+	}
+	return Base{
+		Name:    s.Name,
+		Channel: s.Channel.Clean(),
+	}
+}
+
+// IsCanonical returns true if the base is already in its canonical form.
+func (s Base) IsCanonical() bool {
+	return s == s.Canonical()
+}
+
 // String respresentation of the Base, used for series backwards compatability.
+// The string is built from the canonical form of the base, so parsing it with
+// ParseBaseFromSeries returns the canonical base.
 func (s Base) String() string {
+	s = s.Canonical()
 	// Handle legacy series.
 	if series, ok := baseToSeries[s]; ok {
 		return series
@@ -48,8 +69,26 @@ func (s Base) String() string {
 }
 
 // ParseBaseFromSeries matches legacy series like "focal" or parses a base as series string
-// in the form "os/track/risk/branch"
+// in the form "os/track/risk/branch". The returned base is always canonical and
+// ParseBaseFromSeries(base.String()) returns the same base.
+// Series aliases, including deprecated ones, are accepted.
 func ParseBaseFromSeries(s string) (Base, error) {
+	base, _, err := ParseBaseFromSeriesAlias(s)
+	return base, err
+}
+
+// ParseBaseFromSeriesAlias works like ParseBaseFromSeries, but also returns
+// the alias information when s is an alternative series name, so callers can
+// report the use of deprecated names. The returned alias is nil otherwise.
+func ParseBaseFromSeriesAlias(s string) (Base, *SeriesAlias, error) {
+	if alias, ok := seriesAliases[s]; ok {
+		return seriesToBases[alias.Preferred], &alias, nil
+	}
+	base, err := parseBase(s)
+	return base, nil, err
+}
+
+func parseBase(s string) (Base, error) {
 	var err error
 	if base, ok := seriesToBases[s]; ok {
 		return base, nil
@@ -0,0 +1,41 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the LGPLv3, see LICENCE file for details.
+
+//go:build go1.18
+// +build go1.18
+
+package systems_test
+
+import (
+	stdtesting "testing"
+
+	"github.com/juju/systems"
+)
+
+// FuzzParseBaseFromSeries checks that Parse→String→Parse is a fixed point
+// for every base string the parser accepts.
+func FuzzParseBaseFromSeries(f *stdtesting.F) {
+	for _, seed := range baseSeeds {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *stdtesting.T, str string) {
+		base, err := systems.ParseBaseFromSeries(str)
+		if err != nil {
+			return
+		}
+		if !base.IsCanonical() {
+			t.Fatalf("ParseBaseFromSeries(%q) returned non canonical base %#v", str, base)
+		}
+		out := base.String()
+		again, err := systems.ParseBaseFromSeries(out)
+		if err != nil {
+			t.Fatalf("ParseBaseFromSeries(%q) failed to parse %q: %v", str, out, err)
+		}
+		if again != base {
+			t.Fatalf("ParseBaseFromSeries(%q) = %#v, reparsed %q as %#v", str, base, out, again)
+		}
+		if again.String() != out {
+			t.Fatalf("String() of %q not stable: %q != %q", str, again.String(), out)
+		}
+	})
+}
@@ -5,6 +5,7 @@ package systems_test
 
 import (
 	"encoding/json"
+	"sort"
 
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -62,3 +63,118 @@ func (s *systemSuite) TestJSONEncoding(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(sys2, jc.DeepEquals, sys)
 }
+
+func (s *systemSuite) TestCanonical(c *gc.C) {
+	tests := []struct {
+		base      systems.Base
+		canonical systems.Base
+	}{
+		{systems.Base{Name: systems.Ubuntu}, systems.Base{Name: systems.Ubuntu}},
+		{
+			systems.Base{Name: systems.Ubuntu, Channel: channel.MustParse("20.04/edge")},
+			systems.Base{Name: systems.Ubuntu, Channel: channel.MustParse("20.04/edge")},
+		},
+		{
+			systems.Base{Name: systems.Ubuntu, Channel: channel.Channel{Track: "latest", Risk: channel.Stable, Name: "latest/stable"}},
+			systems.Base{Name: systems.Ubuntu, Channel: channel.MustParse("stable")},
+		},
+		{
+			systems.Base{Name: systems.Ubuntu, Channel: channel.Channel{Track: "20.04"}},
+			systems.Base{Name: systems.Ubuntu, Channel: channel.MustParse("20.04/stable")},
+		},
+	}
+	for i, t := range tests {
+		comment := gc.Commentf("test %d", i)
+		c.Check(t.base.Canonical(), jc.DeepEquals, t.canonical, comment)
+		c.Check(t.canonical.IsCanonical(), jc.IsTrue, comment)
+	}
+	c.Check(systems.Base{Name: systems.Ubuntu, Channel: channel.Channel{Track: "20.04"}}.IsCanonical(), jc.IsFalse)
+}
+
+func (s *systemSuite) TestStringUsesCanonicalForm(c *gc.C) {
+	base := systems.Base{Name: systems.Ubuntu, Channel: channel.Channel{Track: "20.04", Risk: channel.Stable}}
+	c.Check(base.String(), gc.Equals, "focal")
+
+	base = systems.Base{Name: systems.Ubuntu, Channel: channel.Channel{Track: "latest", Risk: channel.Stable}}
+	c.Check(base.String(), gc.Equals, "ubuntu/stable")
+}
+
+func (s *systemSuite) TestParseStringFixedPoint(c *gc.C) {
+	for _, str := range baseSeeds {
+		comment := gc.Commentf("%q", str)
+		base, err := systems.ParseBaseFromSeries(str)
+		c.Assert(err, jc.ErrorIsNil, comment)
+		c.Check(base.IsCanonical(), jc.IsTrue, comment)
+		again, err := systems.ParseBaseFromSeries(base.String())
+		c.Assert(err, jc.ErrorIsNil, comment)
+		c.Check(again, jc.DeepEquals, base, comment)
+		c.Check(again.String(), gc.Equals, base.String(), comment)
+	}
+}
+
+var baseSeeds = []string{
+	"focal",
+	"opensuseleap",
+	"genericlinux",
+	"genericlinux/stable",
+	"ubuntu/latest/stable",
+	"ubuntu/stable",
+	"ubuntu/20.04",
+	"ubuntu/20.04/stable",
+	"ubuntu/20.04/edge",
+	"ubuntu/20.04/edge/foo",
+	"ubuntu/edge/foo",
+	"ubuntu/latest/candidate/foo",
+	"windows/win10",
+	"centos/centos7/beta",
+}
+
+func (s *systemSuite) TestParseBaseFromSeriesAlias(c *gc.C) {
+	base, alias, err := systems.ParseBaseFromSeriesAlias("opensuseleap")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(alias, gc.NotNil)
+	c.Check(*alias, jc.DeepEquals, systems.SeriesAlias{
+		Alias:      "opensuseleap",
+		Preferred:  "opensuse42",
+		Deprecated: true,
+	})
+	c.Check(alias.String(), gc.Equals, `series "opensuseleap" is deprecated, use "opensuse42" instead`)
+	c.Check(base, jc.DeepEquals, systems.Base{Name: systems.OpenSUSE, Channel: channel.MustParse("opensuse42/stable")})
+	c.Check(base.String(), gc.Equals, "opensuse42")
+
+	preferred, alias, err := systems.ParseBaseFromSeriesAlias("opensuse42")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(alias, gc.IsNil)
+	c.Check(preferred, jc.DeepEquals, base)
+
+	base, err = systems.ParseBaseFromSeries("opensuseleap")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(base, jc.DeepEquals, preferred)
+
+	_, alias, err = systems.ParseBaseFromSeriesAlias("mythicalos")
+	c.Check(err, gc.ErrorMatches, `series "mythicalos" not valid`)
+	c.Check(alias, gc.IsNil)
+}
+
+func (s *systemSuite) TestLookupSeriesAlias(c *gc.C) {
+	alias, ok := systems.LookupSeriesAlias("opensuseleap")
+	c.Assert(ok, jc.IsTrue)
+	c.Check(alias.Preferred, gc.Equals, "opensuse42")
+
+	_, ok = systems.LookupSeriesAlias("focal")
+	c.Check(ok, jc.IsFalse)
+}
+
+func (s *systemSuite) TestKnownSeries(c *gc.C) {
+	series := systems.KnownSeries()
+	c.Assert(sort.StringsAreSorted(series), jc.IsTrue)
+	known := make(map[string]bool)
+	for _, name := range series {
+		known[name] = true
+		base, err := systems.ParseBaseFromSeries(name)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(base.String(), gc.Equals, name)
+	}
+	c.Check(known["focal"], jc.IsTrue)
+	c.Check(known["opensuseleap"], jc.IsFalse)
+}